@@ -0,0 +1,173 @@
+package conveyor_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+	"github.com/iostrovok/conveyor/testobject"
+)
+
+type batchHandler struct {
+	faces.EmptyHandler
+
+	sync.Mutex
+	sizes *[]int
+}
+
+func (h *batchHandler) RunBatch(items []faces.IItem) []error {
+	h.Lock()
+	*h.sizes = append(*h.sizes, len(items))
+	h.Unlock()
+
+	errs := make([]error, len(items))
+	for i, item := range items {
+		if item.Get().(*pathMessage).id%4 == 0 {
+			errs[i] = errors.New("batch error")
+		}
+	}
+
+	return errs
+}
+
+func (s *testSuite) TestBatchHandler(c *C) {
+	sizes := make([]int, 0)
+	newBatchHandler := func(_ faces.Name) (faces.IBatchHandler, error) {
+		return &batchHandler{sizes: &sizes}, nil
+	}
+
+	cv := conveyor.New(20, faces.ChanStdGo, "batch")
+	c.Assert(cv.AddBatchHandler("batch", 1, 1, 5, 100*time.Millisecond, newBatchHandler), IsNil)
+	c.Assert(cv.AddHandler("next", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	wg := sync.WaitGroup{}
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			res, err := cv.RunRes(input.New().Data(&pathMessage{id: i}))
+			if i%4 == 0 {
+				c.Check(err, ErrorMatches, "batch error")
+				c.Check(len(res.(*pathMessage).path), Equals, 0)
+			} else {
+				c.Check(err, IsNil)
+				c.Check(res.(*pathMessage).path, DeepEquals, []faces.Name{"next"})
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	cv.WaitAndStop()
+
+	total, max := 0, 0
+	for _, size := range sizes {
+		total += size
+		if size > max {
+			max = size
+		}
+	}
+
+	c.Assert(total, Equals, 20)
+	c.Assert(max > 1 && max <= 5, Equals, true)
+}
+
+func (s *testSuite) TestBatchHandlerSingle(c *C) {
+	sizes := make([]int, 0)
+	newBatchHandler := func(_ faces.Name) (faces.IBatchHandler, error) {
+		return &batchHandler{sizes: &sizes}, nil
+	}
+
+	cv := conveyor.New(20, faces.ChanStdGo, "batch")
+	c.Assert(cv.AddBatchHandler("zero", 1, 1, 0, time.Millisecond, newBatchHandler), NotNil)
+	c.Assert(cv.AddBatchHandler("negative", 1, 1, 1, -time.Millisecond, newBatchHandler), NotNil)
+	c.Assert(cv.AddBatchHandler("batch", 1, 1, 1, 0, newBatchHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	for i := 1; i <= 4; i++ {
+		_, err := cv.RunRes(input.New().Data(&pathMessage{id: i}))
+		if i%4 == 0 {
+			c.Assert(err, ErrorMatches, "batch error")
+		} else {
+			c.Assert(err, IsNil)
+		}
+	}
+
+	cv.WaitAndStop()
+	c.Assert(sizes, DeepEquals, []int{1, 1, 1, 1})
+}
+
+// RunBatchTestJazz is called for items which are sent by RunResTest with "Jazz" suffix.
+func (h *batchHandler) RunBatchTestJazz(items []faces.IItem, _ *C) []error {
+	for _, item := range items {
+		item.Get().(*pathMessage).add("jazz")
+	}
+
+	return h.RunBatch(items)
+}
+
+func (s *testSuite) TestBatchHandlerTestMode(c *C) {
+	sizes := make([]int, 0)
+	newBatchHandler := func(_ faces.Name) (faces.IBatchHandler, error) {
+		return &batchHandler{sizes: &sizes}, nil
+	}
+
+	cv := conveyor.New(20, faces.ChanStdGo, "batch")
+	c.Assert(cv.AddBatchHandler("batch", 1, 1, 5, 10*time.Millisecond, newBatchHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	res, err := cv.RunResTest(input.New().Data(&pathMessage{id: 1}), testobject.New(true, c, "Jazz"))
+	c.Assert(err, IsNil)
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"jazz"})
+
+	res, err = cv.RunRes(input.New().Data(&pathMessage{id: 1}))
+	c.Assert(err, IsNil)
+	c.Assert(len(res.(*pathMessage).path), Equals, 0)
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestBatchHandlerStop(c *C) {
+	sizes := make([]int, 0)
+	called := make(chan struct{}, 1)
+	newBatchHandler := func(_ faces.Name) (faces.IBatchHandler, error) {
+		return &notifyBatchHandler{batchHandler: batchHandler{sizes: &sizes}, called: called}, nil
+	}
+
+	cv := conveyor.New(20, faces.ChanStdGo, "batch")
+	c.Assert(cv.AddBatchHandler("batch", 1, 1, 5, time.Hour, newBatchHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	c.Assert(cv.Run(input.New().Data(&pathMessage{id: 1})), IsNil)
+	time.Sleep(10 * time.Millisecond)
+
+	// the batch which is still filling is processed without waiting
+	cv.Stop()
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		c.Fatal("batch is not interrupted by Stop")
+	}
+
+	c.Assert(sizes, DeepEquals, []int{1})
+}
+
+type notifyBatchHandler struct {
+	batchHandler
+
+	called chan struct{}
+}
+
+func (h *notifyBatchHandler) RunBatch(items []faces.IItem) []error {
+	defer func() { h.called <- struct{}{} }()
+
+	return h.batchHandler.RunBatch(items)
+}
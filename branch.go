@@ -0,0 +1,134 @@
+package conveyor
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/queues"
+)
+
+// Branch is a separate chain of handlers supported the faces.IBranch interface.
+// Items are sent to branch after the fork manager if they are accepted by predicate.
+type Branch struct {
+	conveyor *Conveyor
+
+	name      faces.Name
+	predicate faces.Predicate
+	rejoin    bool
+
+	// the main chain manager after which items are sent to branch
+	fork faces.IManager
+
+	in       faces.IChan
+	handlers []faces.IManager
+}
+
+// AddBranch adds the separate chain of handlers after the last added handler.
+// Items accepted by predicate are sent to the branch instead of the next handler of main chain.
+// If rejoin is true items come back to main chain after the branch, otherwise they go to the final handlers.
+func (c *Conveyor) AddBranch(name faces.Name, predicate faces.Predicate, rejoin bool) (faces.IBranch, error) {
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	if name == "" {
+		return nil, errors.New("branch name can not be empty")
+	}
+
+	if predicate == nil {
+		return nil, errors.New("branch predicate can not be nil")
+	}
+
+	if c.data.lastWorkerManager == nil {
+		return nil, errors.New("branch '" + string(name) + "' should be added after handler")
+	}
+
+	if err := c.checkUniqName(name); err != nil {
+		return nil, err
+	}
+
+	b := &Branch{
+		conveyor:  c,
+		name:      name,
+		predicate: predicate,
+		rejoin:    rejoin,
+		fork:      c.data.lastWorkerManager,
+		in:        queues.New(c.data.workBench, c.data.chanType),
+	}
+
+	c.data.branches = append(c.data.branches, b)
+
+	return b, nil
+}
+
+// Name is a simple getter.
+func (b *Branch) Name() faces.Name {
+	return b.name
+}
+
+// AddHandler adds customer handler to the end of branch.
+// Parameter name should be unique for whole conveyor.
+func (b *Branch) AddHandler(name faces.Name, minCount, maxCount int, handler faces.GiveBirth) error {
+	c := b.conveyor
+
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	if name == "" {
+		return errors.New("handler name can not be empty")
+	}
+
+	if err := c.checkUniqName(name); err != nil {
+		return err
+	}
+
+	c.data.managerCounter++
+
+	next := c.newWorkerManager(name, minCount, maxCount, handler)
+
+	if last := b.last(); last != nil {
+		in := queues.New(c.data.workBench, c.data.chanType)
+		last.SetNextManager(next).SetChanOut(in)
+		next.SetPrevManager(last).SetChanIn(in)
+	} else {
+		next.SetChanIn(b.in)
+	}
+
+	b.handlers = append(b.handlers, next)
+
+	return nil
+}
+
+// last returns the last manager of branch or nil.
+func (b *Branch) last() faces.IManager {
+	if len(b.handlers) == 0 {
+		return nil
+	}
+
+	return b.handlers[len(b.handlers)-1]
+}
+
+// wire links the branch with the main chain. It's called once before start.
+func (b *Branch) wire(errCh *queues.Joint) error {
+	last := b.last()
+	if last == nil {
+		return errors.New("branch '" + string(b.name) + "' has no handlers")
+	}
+
+	b.fork.AddRoute(faces.Route{Name: b.handlers[0].Name(), Ch: b.in, Predicate: b.predicate})
+
+	target := b.fork.GetNextManager()
+	if !b.rejoin || target == nil {
+		// the end of branch is the end of conveyor
+		last.SetIsLast(true).SetChanOut(b.conveyor.data.outCh).SetChanErr(errCh.AddProducer())
+
+		return nil
+	}
+
+	// the main chain and branch are filling the same channel now
+	in := queues.NewJoint(target.GetChanIn())
+	b.fork.SetChanOut(in.AddProducer())
+	last.SetNextManager(target).SetChanOut(in.AddProducer())
+	target.SetChanIn(in)
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package conveyor_test
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type pathMessage struct {
+	sync.Mutex
+
+	id   int
+	path []faces.Name
+}
+
+func (m *pathMessage) add(name faces.Name) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.path = append(m.path, name)
+}
+
+type pathHandler struct {
+	faces.EmptyHandler
+
+	name faces.Name
+}
+
+func newPathHandler(name faces.Name) (faces.IHandler, error) {
+	return &pathHandler{name: name}, nil
+}
+
+func (h *pathHandler) Run(item faces.IItem) error {
+	item.Get().(*pathMessage).add(h.name)
+
+	return nil
+}
+
+func isEven(item faces.IItem) bool {
+	return item.Get().(*pathMessage).id%2 == 0
+}
+
+func (s *testSuite) TestBranch(c *C) {
+	for _, rejoin := range []bool{true, false} {
+		cv := conveyor.New(10, faces.ChanStdGo, "branch")
+
+		c.Assert(cv.AddHandler("first", 1, 2, newPathHandler), IsNil)
+
+		branch, err := cv.AddBranch("even", isEven, rejoin)
+		c.Assert(err, IsNil)
+		c.Assert(branch.AddHandler("even-1", 1, 2, newPathHandler), IsNil)
+		c.Assert(branch.AddHandler("even-2", 1, 2, newPathHandler), IsNil)
+
+		c.Assert(cv.AddHandler("second", 1, 2, newPathHandler), IsNil)
+		c.Assert(cv.Start(context.Background()), IsNil)
+
+		for i := 0; i < 10; i++ {
+			res, err := cv.RunRes(input.New().Data(&pathMessage{id: i}))
+			c.Assert(err, IsNil)
+
+			path := res.(*pathMessage).path
+			switch {
+			case i%2 == 1:
+				c.Assert(path, DeepEquals, []faces.Name{"first", "second"})
+			case rejoin:
+				c.Assert(path, DeepEquals, []faces.Name{"first", "even-1", "even-2", "second"})
+			default:
+				c.Assert(path, DeepEquals, []faces.Name{"first", "even-1", "even-2"})
+			}
+		}
+
+		cv.WaitAndStop()
+	}
+}
+
+func (s *testSuite) TestBranchErrors(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "branch")
+
+	_, err := cv.AddBranch("even", isEven, true)
+	c.Assert(err, NotNil)
+
+	c.Assert(cv.AddHandler("first", 1, 2, newPathHandler), IsNil)
+
+	_, err = cv.AddBranch("even", nil, true)
+	c.Assert(err, NotNil)
+
+	_, err = cv.AddBranch("first", isEven, true)
+	c.Assert(err, NotNil)
+
+	_, err = cv.AddBranch("even", isEven, true)
+	c.Assert(err, IsNil)
+
+	// branch without handlers
+	c.Assert(cv.Start(context.Background()), NotNil)
+}
@@ -0,0 +1,75 @@
+package conveyor_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+	"github.com/iostrovok/conveyor/protobuf/go/nodes"
+)
+
+type downHandler struct {
+	faces.EmptyHandler
+}
+
+func newDownHandler(_ faces.Name) (faces.IHandler, error) {
+	return &downHandler{}, nil
+}
+
+func (h *downHandler) Run(_ faces.IItem) error {
+	return errors.New("dependency is down")
+}
+
+func (s *testSuite) TestBreakerFailFast(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "breaker")
+	c.Assert(cv.AddHandler("api", 1, 1, newDownHandler), IsNil)
+	c.Assert(cv.AddHandler("next", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.SetBreaker("api", faces.BreakerConfig{MinRequests: 3, Cooldown: time.Hour}), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	for i := 0; i < 5; i++ {
+		_, err := cv.RunRes(input.New().Data(&pathMessage{id: i}))
+		c.Assert(err, NotNil)
+
+		var open *faces.ErrCircuitOpen
+		c.Assert(errors.As(err, &open), Equals, i >= 3, Commentf("item %d: %v", i, err))
+	}
+
+	var breaker *nodes.BreakerData
+	for _, md := range cv.Statistic().ManagerData {
+		if md.Name == "api" {
+			breaker = md.Breaker
+		}
+	}
+
+	c.Assert(breaker, NotNil)
+	c.Assert(breaker.State, Equals, nodes.BreakerState_BREAKER_OPEN)
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestBreakerBypass(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "breaker")
+	c.Assert(cv.AddHandler("api", 1, 1, newDownHandler), IsNil)
+	c.Assert(cv.AddHandler("next", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.SetBreaker("api", faces.BreakerConfig{MinRequests: 2, Cooldown: time.Hour, Policy: faces.BreakerBypass}), IsNil)
+	c.Assert(cv.SetBreaker("unknown", faces.BreakerConfig{}), NotNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	for i := 0; i < 4; i++ {
+		res, err := cv.RunRes(input.New().Data(&pathMessage{id: i}))
+		if i < 2 {
+			c.Assert(err, NotNil)
+		} else {
+			c.Assert(err, IsNil)
+			c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"next"})
+		}
+	}
+
+	cv.WaitAndStop()
+}
@@ -0,0 +1,146 @@
+package conveyor_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+var errAbort = errors.New("aborted by user")
+
+type longHandler struct {
+	faces.EmptyHandler
+
+	ids chan int64
+}
+
+// Run reports the id of item and works until the item context is done.
+func (h *longHandler) Run(item faces.IItem) error {
+	h.ids <- item.GetID()
+	<-item.GetContext().Done()
+
+	return item.GetContext().Err()
+}
+
+func (s *testSuite) TestCancel(c *C) {
+	ids := make(chan int64, 1)
+	newLongHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &longHandler{ids: ids}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "cancel")
+	c.Assert(cv.AddHandler("long", 1, 1, newLongHandler), IsNil)
+	c.Assert(cv.AddHandler("last", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("errors", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	c.Assert(cv.Cancel(-1, errAbort), Equals, false)
+
+	msg := &pathMessage{}
+	done := make(chan error, 1)
+	go func() {
+		_, err := cv.RunRes(input.New().Data(msg))
+		done <- err
+	}()
+
+	c.Assert(cv.Cancel(<-ids, errAbort), Equals, true)
+	c.Assert(<-done, Equals, errAbort)
+
+	cv.WaitAndStop()
+
+	// canceled item is processed by error handlers only
+	c.Assert(msg.path, DeepEquals, []faces.Name{"errors"})
+}
+
+type idHandler struct {
+	faces.EmptyHandler
+
+	ids chan int64
+}
+
+// Run reports the id of item.
+func (h *idHandler) Run(item faces.IItem) error {
+	h.ids <- item.GetID()
+
+	return nil
+}
+
+func (s *testSuite) TestCancelInQueue(c *C) {
+	received, busy := make(chan int64, 2), make(chan int64, 2)
+	newIDHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &idHandler{ids: received}, nil
+	}
+	newLongHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &longHandler{ids: busy}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "cancel")
+	c.Assert(cv.AddHandler("first", 1, 1, newIDHandler), IsNil)
+	c.Assert(cv.AddHandler("long", 1, 1, newLongHandler), IsNil)
+	c.Assert(cv.AddHandler("last", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := cv.RunRes(input.New().Data(&pathMessage{}))
+			done <- err
+		}()
+	}
+
+	ids := []int64{<-received, <-received}
+
+	// the busy item blocks the single worker, another one is waiting in queue
+	inWork := <-busy
+	for _, id := range ids {
+		if id != inWork {
+			c.Assert(cv.Cancel(id, nil), Equals, true)
+			c.Assert(<-done, ErrorMatches, fmt.Sprintf("item %d is canceled", id))
+		}
+	}
+
+	c.Assert(cv.Cancel(inWork, nil), Equals, true)
+	c.Assert(<-done, ErrorMatches, fmt.Sprintf("item %d is canceled", inWork))
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestItemContextInErrorHandler(c *C) {
+	ids, release := make(chan int64, 1), make(chan struct{})
+	newGateHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateHandler{ids: ids, release: release}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "item-context")
+	c.Assert(cv.AddHandler("fail", 1, 1, newFailHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("gate", 1, 1, newGateHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Assert(cv.Run(input.New().Context(ctx).Data(&pathMessage{id: 3})), IsNil)
+
+	// error handler doesn't wait for the item which context is done without cancel reason
+	<-ids
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		cv.WaitAndStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		c.Fatal("error handler waits for the item with done context")
+	}
+
+	close(release)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/iostrovok/conveyor/item"
 	"github.com/iostrovok/conveyor/protobuf/go/nodes"
 	"github.com/iostrovok/conveyor/queues"
+	"github.com/iostrovok/conveyor/scatter"
 	"github.com/iostrovok/conveyor/slavenode"
 	"github.com/iostrovok/conveyor/testobject"
 	"github.com/iostrovok/conveyor/workbench"
@@ -27,9 +28,11 @@ import (
 )
 
 const (
-	defaultFinalName faces.Name = "final-system-handler"
-	defaultErrorName faces.Name = "error-empty-handler"
-	defaultPriority             = 0
+	defaultFinalName  faces.Name = "final-system-handler"
+	defaultErrorName  faces.Name = "error-empty-handler"
+	defaultPriority              = 0
+	defaultMaxHops               = 100
+	defaultMaxResumes            = 10
 )
 
 // DefaultMetricPeriodDuration set up by default default for send/print metric.
@@ -50,11 +53,24 @@ type data struct {
 	clusterID string
 	name      string
 	itemID    *int64
-	isRun     bool
+	state     faces.ConveyorState
+
+	// submissions which are pushing items to conveyor
+	submits sync.WaitGroup
+
+	// it's closed by Stop, the draining doesn't wait for items more
+	stopped chan struct{}
+
+	// it's canceled by Shutdown, the submissions which are waiting for a place in work bench are rejected
+	submitCtx     context.Context
+	cancelSubmits context.CancelFunc
 
 	// storage for items
 	workBench faces.IWorkBench
 
+	// storage for items which are waiting for result
+	results *internalmanager.Results
+
 	workBranchLength int
 	chanType         faces.ChanType
 	errCh            faces.IChan
@@ -68,6 +84,21 @@ type data struct {
 	lastWorkerManager  faces.IManager
 	firstErrorManager  faces.IManager
 	lastErrorManager   faces.IManager
+	branches           []*Branch
+	graphManagers      []faces.IManager
+	subConveyors       []*subConveyor
+	windows            []*windowStage
+	windowsHeld        *signal
+	windowsCtx         context.Context // it's canceled when windows are not closed more, see haltWindows
+	cancelWindows      context.CancelFunc
+	windowsGroup       sync.WaitGroup
+	windowsMu          sync.RWMutex
+	splitter           *splitter
+	resequencer        *resequencer
+	deadLetters        faces.IDeadLetterStore
+	errorMatchers      map[faces.Name][]faces.ErrorMatcher
+	subscribers        *subscribers
+	terminalManagers   []faces.IManager // the last managers of graph
 
 	metricPeriodDuration time.Duration
 	workersCounter       faces.IWorkersCounter
@@ -83,6 +114,9 @@ type data struct {
 	slaveNode         *slavenode.SlaveNode
 
 	defaultPriority int
+	maxHops         int
+	maxResumes      int
+	keepItemError   bool
 	uniqNames       []faces.Name
 
 	// need to use in test mode
@@ -115,8 +149,13 @@ func (c *Conveyor) Init(workBranchLength int, chType faces.ChanType, name string
 
 	c.data = &data{
 		workBench:            workbench.New(workBranchLength),
+		results:              internalmanager.New(),
+		subscribers:          &subscribers{},
+		windowsHeld:          &signal{},
+		stopped:              make(chan struct{}),
 		clusterID:            name + "-" + strconv.FormatInt(time.Now().Unix(), 10),
 		name:                 name,
+		state:                faces.ConveyorCreated,
 		workBranchLength:     workBranchLength,
 		chanType:             chType,
 		workerGroup:          &sync.WaitGroup{},
@@ -130,20 +169,24 @@ func (c *Conveyor) Init(workBranchLength int, chType faces.ChanType, name string
 		workersCounter: workerscounter.New(),
 
 		uniqNames:       []faces.Name{},
+		branches:        []*Branch{},
 		defaultPriority: defaultPriority,
+		maxHops:         defaultMaxHops,
+		maxResumes:      defaultMaxResumes,
 		testObject:      testObject,
 	}
 
 	c.data.outCh = queues.New(c.data.workBench, chType)
 	c.data.inCh = queues.New(c.data.workBench, chType)
 	c.data.errCh = queues.New(c.data.workBench, chType)
+	c.data.submitCtx, c.data.cancelSubmits = context.WithCancel(context.Background())
 
 	c.addSystemFinalHandler()
 
 	return c
 }
 
-// getItemFrommInput excavator IItem or create new.
+// getItemFrommInput excavator IItem or create new. The id is set by numerate when item gets a place in work bench.
 func (c *Conveyor) getItemFrommInput(i faces.IInput) faces.IItem {
 	ctx, tr, val, priorityRef, skipToName := i.Values()
 
@@ -166,40 +209,221 @@ func (c *Conveyor) getItemFrommInput(i faces.IInput) faces.IItem {
 
 	it.SetPriority(priority)
 	it.Set(val)
-	it.SetID(atomic.AddInt64(c.data.itemID, 1))
 	if skipToName != "" {
 		it.SetSkipToName(skipToName)
 	}
 
+	if key := i.GetKey(); key != "" {
+		it.SetKey(key)
+	}
+
 	return it
 }
 
+// take waits for a place of item in work bench. The waiting is interrupted by Shutdown, faces.ErrStopping is returned then.
+func (c *Conveyor) take(it faces.IItem) (int, error) {
+	index, err := c.data.workBench.AddContext(c.data.submitCtx, it)
+	if err != nil {
+		return -1, faces.ErrStopping
+	}
+
+	return index, nil
+}
+
+// numerate sets up the next id for item which has got a place in work bench.
+// The ids go in order of getting the places, the item which doesn't get a place makes no gap for resequencer.
+func (c *Conveyor) numerate(it faces.IItem) {
+	it.SetID(atomic.AddInt64(c.data.itemID, 1))
+}
+
+// State returns the lifecycle state of conveyor.
+func (c *Conveyor) State() faces.ConveyorState {
+	c.data.RLock()
+	defer c.data.RUnlock()
+
+	return c.data.state
+}
+
+// beginSubmit registers the submission of item, the submission calls endSubmit when item is pushed to conveyor.
+// WaitAndStop waits for registered submissions before income channel is closed.
+func (c *Conveyor) beginSubmit() error {
+	c.data.RLock()
+	defer c.data.RUnlock()
+
+	switch c.data.state {
+	case faces.ConveyorCreated:
+		return faces.ErrNotStarted
+	case faces.ConveyorStarted:
+		c.data.submits.Add(1)
+
+		return nil
+	default:
+		return faces.ErrStopping
+	}
+}
+
+// endSubmit marks the registered submission as finished, see beginSubmit.
+func (c *Conveyor) endSubmit() {
+	c.data.submits.Done()
+}
+
 // RunTest creates the new item over interface and sends to conveyor.
 // If priority queue is used the default priority will be set up.
-func (c *Conveyor) RunTest(i faces.IInput, testObject faces.ITestObject) {
+func (c *Conveyor) RunTest(i faces.IInput, testObject faces.ITestObject) error {
+	if err := c.beginSubmit(); err != nil {
+		return err
+	}
+	defer c.endSubmit()
+
 	it := c.getItemFrommInput(i)
 
 	// set test suffix
 	it.SetTestObject(testObject)
 
-	// marker before pushing to first channel
-	it.PushedToChannel(c.data.firstWorkerManager.Name())
-	it.Start()
-	c.data.inCh.ChanIn() <- c.WorkBench().Add(it)
+	index, err := c.take(it)
+	if err != nil {
+		return err
+	}
+
+	c.numerate(it)
+	c.send(it, index)
+
+	return nil
 }
 
 // Run creates the new item over interface and sends to conveyor.
 // If priority queue is used the default priority will be set up.
-func (c *Conveyor) Run(i faces.IInput) {
+// It returns faces.ErrNotStarted or faces.ErrStopping if conveyor doesn't take items
+// or it's shut down while Run is waiting for a room for the item.
+func (c *Conveyor) Run(i faces.IInput) error {
+	if err := c.beginSubmit(); err != nil {
+		return err
+	}
+	defer c.endSubmit()
+
+	it := c.getItemFrommInput(i)
+
+	index, err := c.take(it)
+	if err != nil {
+		return err
+	}
+
+	c.numerate(it)
+	c.send(it, index)
+
+	return nil
+}
+
+// TryRun creates the new item over interface and sends to conveyor if it has a room for the item.
+// It returns faces.ErrConveyorFull without waiting otherwise.
+func (c *Conveyor) TryRun(i faces.IInput) (int64, error) {
+	if err := c.beginSubmit(); err != nil {
+		return 0, err
+	}
+	defer c.endSubmit()
+
 	it := c.getItemFrommInput(i)
+
+	index, ok := c.WorkBench().TryAdd(it)
+	if !ok {
+		return 0, faces.ErrConveyorFull
+	}
+
+	c.numerate(it)
+	c.send(it, index)
+
+	return it.GetID(), nil
+}
+
+// RunContext creates the new item over interface and sends to conveyor.
+// It waits for a room for the item while context is not done. The context is not used by item,
+// see faces.IInput.Context.
+func (c *Conveyor) RunContext(ctx context.Context, i faces.IInput) (int64, error) {
+	if err := c.beginSubmit(); err != nil {
+		return 0, err
+	}
+	defer c.endSubmit()
+
+	it := c.getItemFrommInput(i)
+
+	// the waiting is interrupted by Shutdown too
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-c.data.submitCtx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	index, err := c.WorkBench().AddContext(ctx, it)
+	if err != nil {
+		if c.data.submitCtx.Err() != nil {
+			return 0, faces.ErrStopping
+		}
+
+		return 0, err
+	}
+
+	c.numerate(it)
+	c.send(it, index)
+
+	return it.GetID(), nil
+}
+
+// send pushes the item which is put in work bench to the first handler.
+func (c *Conveyor) send(it faces.IItem, index int) {
 	// marker before pushing to first channel
 	it.PushedToChannel(c.data.firstWorkerManager.Name())
 	it.Start()
-	c.data.inCh.ChanIn() <- c.WorkBench().Add(it)
+	c.data.inCh.ChanIn() <- index
+}
+
+// Cancel stops the item by id which is in conveyor. Item context is canceled and item is processed
+// only by the error and final handlers, reason is its error. Pending RunRes for item returns the reason.
+// Cancel returns false if item is not found.
+func (c *Conveyor) Cancel(id int64, reason error) bool {
+	it, find := c.data.workBench.Find(id)
+	if !find {
+		return false
+	}
+
+	if reason == nil {
+		reason = errors.Errorf("item %d is canceled", id)
+	}
+
+	cancelItem(it, reason)
+
+	return true
+}
+
+// cancelItem stops the item with the reason.
+func cancelItem(it faces.IItem, reason error) {
+	// reason is set before the context is canceled, handlers which are waiting for the context see it
+	it.SetCancelReason(reason)
+	it.Stopped()
+	it.Cancel()
+}
+
+// ItemStatus returns the condition of item by id which is in conveyor.
+// It returns false if item is not found, e.g. it has already left the conveyor.
+func (c *Conveyor) ItemStatus(id int64) (*faces.ItemStatus, bool) {
+	it, find := c.data.workBench.Find(id)
+	if !find {
+		return nil, false
+	}
+
+	return it.Status(), true
 }
 
 // RunResTest creates the new item over interface, sends to conveyor and returns result.
 func (c *Conveyor) RunResTest(i faces.IInput, testObject faces.ITestObject) (interface{}, error) {
+	if err := c.beginSubmit(); err != nil {
+		return nil, err
+	}
+
 	it := c.getItemFrommInput(i)
 
 	// set test suffix
@@ -209,25 +433,42 @@ func (c *Conveyor) RunResTest(i faces.IInput, testObject faces.ITestObject) (int
 }
 
 // RunRes creates the new item over interface, sends to conveyor and returns result.
+// The error is the last error of item, the error of error handler replaces it unless SetKeepItemError is used.
 func (c *Conveyor) RunRes(i faces.IInput) (interface{}, error) {
+	if err := c.beginSubmit(); err != nil {
+		return nil, err
+	}
+
 	it := c.getItemFrommInput(i)
 
 	return c._runRes(it)
 }
 
+// _runRes sends the item and waits for result, the submission must be registered by beginSubmit.
 func (c *Conveyor) _runRes(it faces.IItem) (interface{}, error) {
 	ctx := it.GetContext()
 
+	index, err := c.take(it)
+	if err != nil {
+		c.endSubmit()
+
+		return nil, err
+	}
+
+	c.numerate(it)
+
 	// it adds id to the latest system handler which will wait for result, get it and return to channel.
-	ch := internalmanager.AddID(ctx, it.GetID())
+	ch := c.data.results.AddID(it.GetID())
 
-	// marker before pushing to first channel
-	it.PushedToChannel(c.data.firstWorkerManager.Name())
-	it.Start()
-	c.data.inCh.ChanIn() <- c.WorkBench().Add(it)
+	c.send(it, index)
+	c.endSubmit()
 
 	select {
 	case <-ctx.Done():
+		if reason := it.GetCancelReason(); reason != nil {
+			return nil, reason
+		}
+
 		return nil, errors.New("context is canceled in RunRes")
 	case it, ok := <-ch:
 		if !ok || it == nil {
@@ -341,6 +582,32 @@ func (c *Conveyor) SetWorkersCounter(wc faces.IWorkersCounter) faces.IConveyor {
 	return c
 }
 
+// SetMaxHops sets up the max number of IItem.RouteTo calls for single item.
+// Item goes to the error handlers when it exceeds the limit. By default 100.
+func (c *Conveyor) SetMaxHops(maxHops int) faces.IConveyor {
+	c.data.maxHops = maxHops
+
+	return c
+}
+
+// SetMaxResumes sets up the max number of IItem.Resume calls for single item.
+// Item goes to the final handlers with an error when it exceeds the limit. By default 10.
+func (c *Conveyor) SetMaxResumes(maxResumes int) faces.IConveyor {
+	c.data.maxResumes = maxResumes
+
+	return c
+}
+
+// SetKeepItemError turns on keeping the errors of error handlers apart from the error of item.
+// By default the error of error handler replaces the error of item, so RunRes returns it and the next
+// error handlers are matched by it. If keep is true the item keeps the original error and GetHandlerError,
+// the errors of error handlers are collected by IItem.AddHandlingError, see IItem.GetHandlingErrors.
+func (c *Conveyor) SetKeepItemError(keep bool) faces.IConveyor {
+	c.data.keepItemError = keep
+
+	return c
+}
+
 // SetName is a simple setter for name property.
 func (c *Conveyor) SetName(name string) faces.IConveyor {
 	c.data.name = name
@@ -386,17 +653,139 @@ func (c *Conveyor) MetricPeriod(duration time.Duration) faces.IConveyor {
 	c.data.Lock()
 	defer c.data.Unlock()
 
-	mg := c.data.firstWorkerManager
-	for {
-		if mg == nil {
-			break
-		}
-		mg = mg.MetricPeriod(duration).GetNextManager()
+	for _, mg := range c.workerManagers() {
+		mg.MetricPeriod(duration)
 	}
 
 	return c
 }
 
+// workerManagers returns all worker managers: main chain is followed by branches.
+// If graph is used its managers are returned in order of adding.
+func (c *Conveyor) workerManagers() []faces.IManager {
+	if len(c.data.graphManagers) > 0 {
+		return c.data.graphManagers
+	}
+
+	out := make([]faces.IManager, 0)
+	for mg := c.data.firstWorkerManager; mg != nil; mg = mg.GetNextManager() {
+		out = append(out, mg)
+	}
+
+	for _, b := range c.data.branches {
+		out = append(out, b.handlers...)
+	}
+
+	return out
+}
+
+// SetKeyOrder turns on the processing of items with the same key one by one and in order of coming by handler.
+// Items with different keys are still processed in parallel. If keyFn is nil the key of input is used, see faces.IInput.Key.
+func (c *Conveyor) SetKeyOrder(name faces.Name, keyFn faces.KeyFunc) error {
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	if c.data.state != faces.ConveyorCreated {
+		return errors.New("key order can not be set up for running conveyor")
+	}
+
+	mg := c.findWorkerManager(name)
+	if mg == nil {
+		return errors.New("handler '" + string(name) + "' is not found")
+	}
+
+	mg.SetKeyOrder(keyFn)
+
+	return nil
+}
+
+// SetRateLimit sets up the max number of calls of handler per second and burst. All workers of handler share them.
+// Workers wait for permission before each call, the waiting items are shown in statistic as items in queue.
+func (c *Conveyor) SetRateLimit(name faces.Name, perSecond float64, burst int) error {
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	if perSecond < 0 {
+		return errors.New("rate limit of handler '" + string(name) + "' can not be negative")
+	}
+
+	mg := c.findWorkerManager(name)
+	if mg == nil {
+		return errors.New("handler '" + string(name) + "' is not found")
+	}
+
+	mg.SetRateLimit(perSecond, burst)
+
+	return nil
+}
+
+// SetBreaker sets up the circuit breaker of handler. The breaker opens when the rate of handler errors
+// in the sliding window crosses the threshold. Items are failed with faces.ErrCircuitOpen or bypassed to the next handler
+// while it's open, see faces.BreakerPolicy. After cooldown the single item probes the handler.
+func (c *Conveyor) SetBreaker(name faces.Name, config faces.BreakerConfig) error {
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	mg := c.findWorkerManager(name)
+	if mg == nil {
+		return errors.New("handler '" + string(name) + "' is not found")
+	}
+
+	mg.SetBreaker(config)
+
+	return nil
+}
+
+// SetRetry sets up the policy of retrying the failed items by handler. The item is processed again by the same handler
+// after the backoff delay, only items which exhaust their attempts go to the error handlers.
+// The number of attempt is available in handler by faces.IItem.GetAttempts. Batch handlers are not retried.
+func (c *Conveyor) SetRetry(name faces.Name, policy faces.RetryPolicy) error {
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	mg := c.findWorkerManager(name)
+	if mg == nil {
+		return errors.New("handler '" + string(name) + "' is not found")
+	}
+
+	mg.SetRetry(policy)
+
+	return nil
+}
+
+// SetTimeout sets up the max time of single handler call. The item which is not processed in time is failed
+// with faces.ErrStageTimeout and goes to the error handlers as soon as the timeout expires, the stuck worker
+// is replaced by new one. The handler gets the copy of item (see faces.IItem.Detach), so the hung call doesn't
+// change the item after timeout. The item context is not cancelled by timeout. Zero timeout means no limit.
+func (c *Conveyor) SetTimeout(name faces.Name, timeout time.Duration) error {
+	if timeout < 0 {
+		return errors.New("timeout of handler '" + string(name) + "' can not be negative")
+	}
+
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	mg := c.findWorkerManager(name)
+	if mg == nil {
+		return errors.New("handler '" + string(name) + "' is not found")
+	}
+
+	mg.SetTimeout(timeout)
+
+	return nil
+}
+
+// findWorkerManager returns the worker manager by name or nil.
+func (c *Conveyor) findWorkerManager(name faces.Name) faces.IManager {
+	for _, mg := range c.workerManagers() {
+		if mg.Name() == name {
+			return mg
+		}
+	}
+
+	return nil
+}
+
 func (c *Conveyor) startGroup(manager faces.IManager) error {
 	for {
 		if manager == nil {
@@ -411,10 +800,32 @@ func (c *Conveyor) startGroup(manager faces.IManager) error {
 	return nil
 }
 
+// stageChan is the input channel of handler for IItem.RouteTo and IItem.Resume.
+// It's not active since the conveyor is stopped, the input channels are closed after that.
+type stageChan struct {
+	faces.IChan
+
+	stopped chan struct{}
+}
+
+// IsActive returns false if the conveyor is stopped.
+func (s *stageChan) IsActive() bool {
+	select {
+	case <-s.stopped:
+		return false
+	default:
+		return true
+	}
+}
+
 // Start starts the conveyor.
+// Conveyor can't be started again after Stop or WaitAndStop, it returns faces.ErrStopping.
 func (c *Conveyor) Start(ctx context.Context) error {
-	if c.data.isRun {
+	switch c.State() {
+	case faces.ConveyorStarted:
 		return nil
+	case faces.ConveyorDraining, faces.ConveyorStopped:
+		return faces.ErrStopping
 	}
 
 	// adds default error manager if it's necessary
@@ -432,22 +843,97 @@ func (c *Conveyor) Start(ctx context.Context) error {
 	c.data.lastErrorManager.SetIsLast(true).SetChanErr(c.data.outCh).SetChanOut(c.data.outCh)
 
 	// marks the lastWorkerManager handler. It's lastWorkerManager handler manager, not final.
+	// Error channel is closed when the main chain and all branches which don't rejoin are finished.
+	errCh := queues.NewJoint(c.data.errCh)
 	c.data.firstWorkerManager.SetChanIn(c.data.inCh)
-	c.data.lastWorkerManager.SetIsLast(true).SetChanOut(c.data.outCh)
+
+	terminals := c.data.terminalManagers
+	if c.data.lastWorkerManager != nil {
+		terminals = append(terminals, c.data.lastWorkerManager)
+	}
+
+	for _, mg := range terminals {
+		mg.SetIsLast(true).SetChanOut(c.data.outCh).SetChanErr(errCh.AddProducer())
+	}
+
+	for _, b := range c.data.branches {
+		if err := b.wire(errCh); err != nil {
+			return err
+		}
+	}
+
+	// input channels of all handlers for IItem.RouteTo
+	stages := map[faces.Name]faces.IChan{}
+	for _, mg := range c.workerManagers() {
+		stages[mg.Name()] = &stageChan{IChan: mg.GetChanIn(), stopped: c.data.stopped}
+	}
+
+	split := newSplitter(c)
+	c.data.splitter = split
+	for _, mg := range c.workerManagers() {
+		mg.SetStages(stages, c.data.maxHops).SetSplitter(split)
+	}
+
+	// error handlers send the repaired items back by IItem.Resume
+	for mg := c.data.firstErrorManager; mg != nil; mg = mg.GetNextManager() {
+		mg.SetStages(stages, c.data.maxHops).
+			SetMaxResumes(c.data.maxResumes).
+			SetKeepItemError(c.data.keepItemError)
+	}
+
+	// children leave the conveyor from the final or error handlers
+	for _, first := range []faces.IManager{c.data.systemFinalManager, c.data.firstErrorManager} {
+		for mg := first; mg != nil; mg = mg.GetNextManager() {
+			mg.SetSplitter(split)
+		}
+	}
+
+	// items leave the conveyor from the last final handler
+	for mg := c.data.systemFinalManager; mg != nil; mg = mg.GetNextManager() {
+		mg.SetOnLeave(c.leave)
+	}
+
+	c.classifyErrors()
+
+	if c.data.resequencer != nil {
+		c.data.resequencer.wire(c.data.outCh, c.data.systemFinalManager, c.data.userFinalManager)
+	}
 
 	// adds default final manager
-	c.data.isRun = true
+	c.data.state = faces.ConveyorStarted
 	c.data.stopContext, c.data.cancelContext = context.WithCancel(ctx)
 
+	c.data.windowsCtx, c.data.cancelWindows = context.WithCancel(c.data.stopContext)
+	for _, w := range c.data.windows {
+		w.in = stages[w.name]
+		c.data.windowsGroup.Add(1)
+		go w.run(c.data.windowsCtx)
+	}
+
+	if c.data.resequencer != nil {
+		go c.data.resequencer.run(c.data.stopContext)
+	}
+
+	// inner conveyors are ready before the first item comes
+	for _, sub := range c.data.subConveyors {
+		if err := sub.inner.Start(c.data.stopContext); err != nil {
+			return err
+		}
+	}
+
 	// start all groups
-	for _, first := range []faces.IManager{
-		c.data.systemFinalManager, c.data.firstErrorManager, c.data.firstWorkerManager,
-	} {
+	for _, first := range []faces.IManager{c.data.systemFinalManager, c.data.firstErrorManager} {
 		if err := c.startGroup(first); err != nil {
 			return err
 		}
 	}
 
+	for _, mg := range c.workerManagers() {
+		if err := mg.Start(c.data.stopContext); err != nil {
+			return err
+		}
+	}
+
 	// sending information about cluster to
 	c.runMasterNode()
 
@@ -472,42 +958,91 @@ func (c *Conveyor) tracerFlush(ctx context.Context) {
 }
 
 // Stop stops the conveyor.
-// Processing of items will be  interrupted.
+// Processing of items will be  interrupted. The draining conveyor is stopped too, WaitAndStop doesn't wait for items more.
 func (c *Conveyor) Stop() {
 	c.data.Lock()
 	defer c.data.Unlock()
 
-	if !c.data.isRun {
+	if c.data.state != faces.ConveyorStarted && c.data.state != faces.ConveyorDraining {
 		return
 	}
 
-	mg := c.data.firstWorkerManager
-	for {
-		if mg == nil {
-			return
-		}
+	c.data.state = faces.ConveyorStopped
+	close(c.data.stopped)
+
+	// windows don't send aggregate items to the stage channels which are closed by managers
+	c.haltWindows()
+
+	for _, mg := range c.workerManagers() {
 		mg.Stop()
-		mg = mg.GetNextManager()
+	}
+
+	for _, sub := range c.data.subConveyors {
+		sub.inner.Stop()
 	}
 }
 
 // WaitAndStop waits while all handler are finished and exits.
 // Processing of items will not be interrupted.
+// New items are not taken since the call, items which are being submitted are waited for.
 func (c *Conveyor) WaitAndStop() {
-	if !c.data.isRun {
+	if c.startDrain() != nil {
 		return
 	}
 
+	c.drain()
+}
+
+// startDrain moves the started conveyor to the draining state, new items are not taken since the call.
+func (c *Conveyor) startDrain() error {
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	switch c.data.state {
+	case faces.ConveyorCreated:
+		return faces.ErrNotStarted
+	case faces.ConveyorStarted:
+		c.data.state = faces.ConveyorDraining
+
+		return nil
+	default:
+		return faces.ErrStopping
+	}
+}
+
+// drain waits while all items are processed and stops the draining conveyor.
+func (c *Conveyor) drain() {
+	c.data.submits.Wait()
+
+	// items may be routed back to the first handlers (see IItem.RouteTo),
+	// so income channel is closed when all items are processed.
+	// Items which are held by windows are released when the pending windows are closed.
+	for {
+		if !c.waitHeld() || !c.flushWindows() {
+			break
+		}
+	}
+
+	c.haltWindows()
+
 	// close income channel and wait for all managers are stopped.
 	c.data.inCh.Close()
 	c.data.workerGroup.Wait()
 
+	// inner conveyors get no more items
+	for _, sub := range c.data.subConveyors {
+		sub.inner.WaitAndStop()
+	}
+
 	// waiting error handlers
 	c.data.errorGroup.Wait()
 
 	// waiting error handlers
 	c.data.finalGroup.Wait()
 
+	// nothing leaves the conveyor more
+	c.data.subscribers.close()
+
 	// lastWorkerManager actions
 	if c.data.slaveNode != nil {
 		// ignore all errors
@@ -516,13 +1051,15 @@ func (c *Conveyor) WaitAndStop() {
 
 	c.data.cancelContext()
 	c.flushTrace()
+
+	c.data.Lock()
+	c.data.state = faces.ConveyorStopped
+	c.data.Unlock()
 }
 
 func (c *Conveyor) checkUniqName(manageName faces.Name) error {
-	for _, n := range c.data.uniqNames {
-		if n == manageName {
-			return errors.WithStack(errors.New("not uniq handler name '" + string(manageName) + "'"))
-		}
+	if c.hasName(manageName) {
+		return errors.WithStack(errors.New("not uniq handler name '" + string(manageName) + "'"))
 	}
 
 	c.data.uniqNames = append(c.data.uniqNames, manageName)
@@ -530,6 +1067,16 @@ func (c *Conveyor) checkUniqName(manageName faces.Name) error {
 	return nil
 }
 
+func (c *Conveyor) hasName(manageName faces.Name) bool {
+	for _, n := range c.data.uniqNames {
+		if n == manageName {
+			return true
+		}
+	}
+
+	return false
+}
+
 // AddFinalHandler adds customer final handler as the latest handler from all.
 // Only single customer final handler is allow.
 // If custom final handler returned error the conveyor doesn't process and log it.
@@ -579,7 +1126,7 @@ func (c *Conveyor) addSystemFinalHandler() {
 	c.data.managerCounter++
 	c.data.uniqNames = append(c.data.uniqNames, defaultFinalName)
 
-	handler := internalmanager.Init()
+	handler := c.data.results.Init()
 
 	c.data.systemFinalManager = workers.NewManager(
 		defaultFinalName,
@@ -610,22 +1157,17 @@ func (c *Conveyor) AddHandler(name faces.Name, minCount, maxCount int, handler f
 		return errors.New("handler name can not be empty")
 	}
 
+	if len(c.data.graphManagers) > 0 {
+		return errors.New("handlers are defined by graph")
+	}
+
 	if err := c.checkUniqName(name); err != nil {
 		return err
 	}
 
 	c.data.managerCounter++
 
-	next := workers.NewManager(
-		name, faces.WorkerManagerType, c.data.workBench,
-		c.data.workBranchLength, minCount, maxCount, c.data.tracer,
-	).
-		SetHandler(handler).
-		SetChanErr(c.data.errCh).
-		SetWaitGroup(c.data.workerGroup).
-		MetricPeriod(c.data.metricPeriodDuration).
-		SetWorkersCounter(c.data.workersCounter).
-		SetTestMode(c.data.testObject)
+	next := c.newWorkerManager(name, minCount, maxCount, handler)
 
 	if c.data.lastWorkerManager != nil {
 		in := queues.New(c.data.workBench, c.data.chanType)
@@ -641,10 +1183,70 @@ func (c *Conveyor) AddHandler(name faces.Name, minCount, maxCount int, handler f
 	return nil
 }
 
+// AddBatchHandler adds customer handler which processes several items at once.
+// Each worker collects up to maxBatch items or as many as it gets during maxWait and passes them to RunBatch.
+// Items with errors are sent to the error handlers one by one. MaxBatch should be positive, maxWait can not be negative.
+func (c *Conveyor) AddBatchHandler(name faces.Name, minCount, maxCount, maxBatch int, maxWait time.Duration,
+	handler faces.GiveBirthBatch) error {
+	if maxBatch < 1 {
+		return errors.Errorf("batch handler '%s': max batch %d should be positive", name, maxBatch)
+	}
+
+	if maxWait < 0 {
+		return errors.Errorf("batch handler '%s': max wait %s can not be negative", name, maxWait)
+	}
+
+	giveBirth := func(name faces.Name) (faces.IHandler, error) {
+		return handler(name)
+	}
+
+	if err := c.AddHandler(name, minCount, maxCount, giveBirth); err != nil {
+		return err
+	}
+
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	c.data.lastWorkerManager.SetBatch(maxBatch, maxWait)
+
+	return nil
+}
+
+// AddScatterHandler adds the stage which hands each item to all handlers at the same time.
+// The item goes to the next handler when all of them are finished. Each handler sets own result by IItem.Set,
+// join makes the new data of item from them (scatter.JoinResults if it's nil).
+// Errors of handlers are merged into the item error (see scatter.Errors), the data is not changed then.
+func (c *Conveyor) AddScatterHandler(name faces.Name, minCount, maxCount int, join faces.JoinFunc,
+	handlers ...faces.GiveBirth) error {
+	if len(handlers) == 0 {
+		return errors.New("scatter handler '" + string(name) + "' has no handlers")
+	}
+
+	return c.AddHandler(name, minCount, maxCount, scatter.New(join, handlers...))
+}
+
+// newWorkerManager creates the manager for customer handler.
+func (c *Conveyor) newWorkerManager(name faces.Name, minCount, maxCount int, handler faces.GiveBirth) faces.IManager {
+	return workers.NewManager(
+		name, faces.WorkerManagerType, c.data.workBench,
+		c.data.workBranchLength, minCount, maxCount, c.data.tracer,
+	).
+		SetHandler(handler).
+		SetChanErr(c.data.errCh).
+		SetWaitGroup(c.data.workerGroup).
+		MetricPeriod(c.data.metricPeriodDuration).
+		SetWorkersCounter(c.data.workersCounter).
+		SetTestMode(c.data.testObject)
+}
+
 // AddErrorHandler adds custom error handler for processing the errors which were returned with work handler.
 // Multiple custom error handlers are allowed.
 // If custom error handler returned error the conveyor logs the error but doesn't process.
-func (c *Conveyor) AddErrorHandler(manageName faces.Name, minCount, maxCount int, handler faces.GiveBirth) error {
+// Handler with matchers processes only items which error is matched by one of them, see faces.ErrorIs,
+// faces.ErrorAs, faces.ErrorFrom and faces.IsPanic. Handlers without matchers process all items
+// if there are no matchers in conveyor or only items which are not matched by any handler otherwise.
+func (c *Conveyor) AddErrorHandler(manageName faces.Name, minCount, maxCount int, handler faces.GiveBirth,
+	matchers ...faces.ErrorMatcher) error {
 	c.data.Lock()
 	defer c.data.Unlock()
 
@@ -684,6 +1286,14 @@ func (c *Conveyor) AddErrorHandler(manageName faces.Name, minCount, maxCount int
 		c.data.firstErrorManager = next
 	}
 
+	if len(matchers) > 0 {
+		if c.data.errorMatchers == nil {
+			c.data.errorMatchers = map[faces.Name][]faces.ErrorMatcher{}
+		}
+
+		c.data.errorMatchers[manageName] = matchers
+	}
+
 	return nil
 }
 
@@ -714,18 +1324,11 @@ func (c *Conveyor) Statistic() *nodes.SlaveNodeInfoRequest {
 		ManagerData: []*nodes.ManagerData{},
 	}
 
-	if !c.data.isRun {
+	if c.data.state == faces.ConveyorCreated {
 		return out
 	}
 
-	mg := c.data.firstWorkerManager
-	for {
-		if mg == nil {
-			break
-		}
-		out.ManagerData = append(out.ManagerData, mg.Statistic())
-		mg = mg.GetNextManager()
-	}
+	out.ManagerData = append(managerStatistic(c.workerManagers()...), c.subConveyorStatistic()...)
 
 	mge := c.data.firstErrorManager
 	for {
@@ -1,6 +1,8 @@
 package conveyor_test
 
 import (
+	"testing"
+
 	_ "github.com/golang/mock/gomock"
 	_ "github.com/golang/mock/mockgen/model"
 	. "github.com/iostrovok/check"
@@ -10,6 +12,8 @@ type testSuite struct{}
 
 var _ = Suite(&testSuite{})
 
+func TestService(t *testing.T) { TestingT(t) }
+
 func (s *testSuite) Testsimple1(c *C) {
 	c.Assert(1, DeepEquals, 1)
 }
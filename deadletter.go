@@ -0,0 +1,101 @@
+package conveyor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+// SetDeadLetterStore sets up the store of items which have left the conveyor with an error,
+// they are saved after the error handlers. Items stopped by Cancel or Shutdown are not saved.
+// See deadletter package for implementations.
+func (c *Conveyor) SetDeadLetterStore(store faces.IDeadLetterStore) faces.IConveyor {
+	c.data.Lock()
+	c.data.deadLetters = store
+	c.data.Unlock()
+
+	c.data.results.SetDeadLetterStore(store)
+
+	return c
+}
+
+// Replay takes the records selected by filter from dead-letter store and sends their payloads to conveyor
+// by RunContext. Items start at the stage, if stage is empty they start at the handler which has failed them
+// or at the first handler if it isn't a worker one. Records which are not sent because the context is done,
+// the conveyor is stopping or the sending has panicked are saved back. Replay returns the number of sent items.
+func (c *Conveyor) Replay(ctx context.Context, filter faces.DeadLetterFilter, stage faces.Name) (int, error) {
+	c.data.RLock()
+	store := c.data.deadLetters
+	state := c.data.state
+	c.data.RUnlock()
+
+	if store == nil {
+		return 0, errors.New("dead-letter store is not set up")
+	}
+
+	if state == faces.ConveyorCreated {
+		return 0, faces.ErrNotStarted
+	}
+
+	if stage != "" && c.findWorkerManager(stage) == nil {
+		return 0, errors.New("handler '" + string(stage) + "' is not found")
+	}
+
+	letters, err := store.Take(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, letter := range letters {
+		if err := c.replayOne(ctx, stage, letter); err != nil {
+			return i, c.saveBack(store, letters[i:], err)
+		}
+	}
+
+	return len(letters), nil
+}
+
+// replayOne sends the payload of record to conveyor, the panic of sending is returned as error.
+func (c *Conveyor) replayOne(ctx context.Context, stage faces.Name, letter *faces.DeadLetter) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = errors.WithStack(&faces.ErrPanic{Value: e})
+		}
+	}()
+
+	in := input.New().Data(letter.Data)
+	if name := c.replayStage(stage, letter); name != "" {
+		in.SkipToName(name)
+	}
+
+	_, err = c.RunContext(ctx, in)
+
+	return err
+}
+
+// replayStage returns the name of handler which the replayed item starts at.
+func (c *Conveyor) replayStage(stage faces.Name, letter *faces.DeadLetter) faces.Name {
+	if stage != "" {
+		return stage
+	}
+
+	if letter.Stage != "" && c.findWorkerManager(letter.Stage) != nil {
+		return letter.Stage
+	}
+
+	return ""
+}
+
+// saveBack returns the records which are not replayed to store.
+func (c *Conveyor) saveBack(store faces.IDeadLetterStore, letters []*faces.DeadLetter, cause error) error {
+	for _, letter := range letters {
+		if err := store.Save(letter); err != nil {
+			return errors.Wrap(err, cause.Error())
+		}
+	}
+
+	return cause
+}
@@ -0,0 +1,97 @@
+package deadletter_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor/deadletter"
+	"github.com/iostrovok/conveyor/faces"
+)
+
+type testSuite struct{}
+
+var _ = Suite(&testSuite{})
+
+func TestService(t *testing.T) { TestingT(t) }
+
+type payload struct {
+	Value int `json:"value"`
+}
+
+func decodePayload(data json.RawMessage) (interface{}, error) {
+	out := &payload{}
+	err := json.Unmarshal(data, out)
+
+	return out, err
+}
+
+func byStage(stage faces.Name) faces.DeadLetterFilter {
+	return func(letter *faces.DeadLetter) bool {
+		return letter.Stage == stage
+	}
+}
+
+func checkStore(c *C, store faces.IDeadLetterStore) {
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := 1; i <= 4; i++ {
+		stage := faces.Name("first")
+		if i%2 == 0 {
+			stage = "second"
+		}
+
+		c.Assert(store.Save(&faces.DeadLetter{ID: int64(i), Data: &payload{Value: i}, Error: "error", Stage: stage, Time: now}), IsNil)
+	}
+
+	all, err := store.List(nil)
+	c.Assert(err, IsNil)
+	c.Assert(len(all), Equals, 4)
+	c.Assert(all[0].Data, DeepEquals, &payload{Value: 1})
+	c.Assert(all[0].Error, Equals, "error")
+	c.Assert(all[0].Time.Equal(now), Equals, true)
+
+	taken, err := store.Take(byStage("second"))
+	c.Assert(err, IsNil)
+	c.Assert(len(taken), Equals, 2)
+	c.Assert(taken[0].ID, Equals, int64(2))
+	c.Assert(taken[1].ID, Equals, int64(4))
+
+	rest, err := store.List(nil)
+	c.Assert(err, IsNil)
+	c.Assert(len(rest), Equals, 2)
+	c.Assert(rest[0].Stage, Equals, faces.Name("first"))
+	c.Assert(rest[1].Data, DeepEquals, &payload{Value: 3})
+
+	taken, err = store.Take(byStage("second"))
+	c.Assert(err, IsNil)
+	c.Assert(len(taken), Equals, 0)
+}
+
+func (s *testSuite) TestMemory(c *C) {
+	checkStore(c, deadletter.NewMemory())
+}
+
+func (s *testSuite) TestFile(c *C) {
+	path := filepath.Join(c.MkDir(), "dead.jsonl")
+
+	store, err := deadletter.NewFile(path, decodePayload)
+	c.Assert(err, IsNil)
+	checkStore(c, store)
+
+	// records are kept in file
+	store, err = deadletter.NewFile(path, nil)
+	c.Assert(err, IsNil)
+
+	all, err := store.List(nil)
+	c.Assert(err, IsNil)
+	c.Assert(len(all), Equals, 2)
+	c.Assert(all[0].Data, DeepEquals, map[string]interface{}{"value": float64(1)})
+}
+
+func (s *testSuite) TestFileError(c *C) {
+	_, err := deadletter.NewFile(filepath.Join(c.MkDir(), "no", "dead.jsonl"), nil)
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,178 @@
+package deadletter
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+const filePerm = 0o644
+
+// Decoder restores the payload of item from JSON.
+type Decoder func(data json.RawMessage) (interface{}, error)
+
+// record is a line of file.
+type record struct {
+	ID    int64           `json:"id"`
+	Data  json.RawMessage `json:"data"`
+	Error string          `json:"error"`
+	Stage faces.Name      `json:"stage"`
+	Time  time.Time       `json:"time"`
+}
+
+// File is a dead-letter store which keeps records in the file, one JSON object per line.
+type File struct {
+	sync.Mutex
+
+	path   string
+	decode Decoder
+}
+
+// NewFile is a constructor. The file is created if it doesn't exist.
+// Decoder restores the payload of item, nil decoder returns the data as it's decoded by encoding/json into interface{}.
+func NewFile(path string, decode Decoder) (*File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, filePerm)
+	if err != nil {
+		return nil, errors.Wrap(err, "dead-letter store")
+	}
+
+	if decode == nil {
+		decode = func(data json.RawMessage) (interface{}, error) {
+			var out interface{}
+			err := json.Unmarshal(data, &out)
+
+			return out, err
+		}
+	}
+
+	return &File{path: path, decode: decode}, f.Close()
+}
+
+// Save appends the record to file.
+func (f *File) Save(letter *faces.DeadLetter) error {
+	line, err := json.Marshal(letter)
+	if err != nil {
+		return errors.Wrap(err, "dead-letter store")
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, filePerm)
+	if err != nil {
+		return errors.Wrap(err, "dead-letter store")
+	}
+
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+
+		return errors.Wrap(err, "dead-letter store")
+	}
+
+	return file.Close()
+}
+
+// List returns the records which are selected by filter.
+func (f *File) List(filter faces.DeadLetterFilter) ([]*faces.DeadLetter, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	letters, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	out, _ := split(letters, filter)
+
+	return out, nil
+}
+
+// Take removes the records which are selected by filter from file and returns them.
+// The rest of records is written to temporary file which replaces the original one.
+func (f *File) Take(filter faces.DeadLetterFilter) ([]*faces.DeadLetter, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	letters, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	out, rest := split(letters, filter)
+	if len(out) == 0 {
+		return out, nil
+	}
+
+	return out, f.write(rest)
+}
+
+func (f *File) read() ([]*faces.DeadLetter, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "dead-letter store")
+	}
+	defer file.Close()
+
+	out := make([]*faces.DeadLetter, 0)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, bufio.MaxScanTokenSize*1024)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		rec := record{}
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, errors.Wrap(err, "dead-letter store")
+		}
+
+		data, err := f.decode(rec.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "dead-letter store")
+		}
+
+		out = append(out, &faces.DeadLetter{ID: rec.ID, Data: data, Error: rec.Error, Stage: rec.Stage, Time: rec.Time})
+	}
+
+	return out, errors.Wrap(scanner.Err(), "dead-letter store")
+}
+
+func (f *File) write(letters []*faces.DeadLetter) error {
+	tmp := f.path + ".tmp"
+
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, filePerm)
+	if err != nil {
+		return errors.Wrap(err, "dead-letter store")
+	}
+
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+
+	for _, letter := range letters {
+		if err := enc.Encode(letter); err != nil {
+			_ = file.Close()
+
+			return errors.Wrap(err, "dead-letter store")
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = file.Close()
+
+		return errors.Wrap(err, "dead-letter store")
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.Wrap(err, "dead-letter store")
+	}
+
+	return errors.Wrap(os.Rename(tmp, f.path), "dead-letter store")
+}
@@ -0,0 +1,71 @@
+/*
+Package deadletter implements the faces.IDeadLetterStore interface in memory and in JSON lines file.
+*/
+package deadletter
+
+import (
+	"sync"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+// Memory is a dead-letter store which keeps records in memory.
+type Memory struct {
+	sync.RWMutex
+
+	letters []*faces.DeadLetter
+}
+
+// NewMemory is a constructor.
+func NewMemory() *Memory {
+	return &Memory{
+		letters: make([]*faces.DeadLetter, 0),
+	}
+}
+
+// Save adds the record to store.
+func (m *Memory) Save(letter *faces.DeadLetter) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.letters = append(m.letters, letter)
+
+	return nil
+}
+
+// List returns the records which are selected by filter.
+func (m *Memory) List(filter faces.DeadLetterFilter) ([]*faces.DeadLetter, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	out, _ := split(m.letters, filter)
+
+	return out, nil
+}
+
+// Take removes the records which are selected by filter from store and returns them.
+func (m *Memory) Take(filter faces.DeadLetterFilter) ([]*faces.DeadLetter, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	out, rest := split(m.letters, filter)
+	m.letters = rest
+
+	return out, nil
+}
+
+// split divides the records to selected by filter and the rest ones.
+func split(letters []*faces.DeadLetter, filter faces.DeadLetterFilter) ([]*faces.DeadLetter, []*faces.DeadLetter) {
+	selected := make([]*faces.DeadLetter, 0)
+	rest := make([]*faces.DeadLetter, 0)
+
+	for _, letter := range letters {
+		if filter == nil || filter(letter) {
+			selected = append(selected, letter)
+		} else {
+			rest = append(rest, letter)
+		}
+	}
+
+	return selected, rest
+}
@@ -0,0 +1,174 @@
+package conveyor_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/deadletter"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type buggyHandler struct {
+	faces.EmptyHandler
+
+	fixed *int32
+}
+
+// Run fails all items until the bug is fixed.
+func (h *buggyHandler) Run(item faces.IItem) error {
+	if atomic.LoadInt32(h.fixed) == 0 {
+		return errors.New("bug")
+	}
+
+	item.Get().(*pathMessage).add("buggy")
+
+	return nil
+}
+
+func (s *testSuite) TestDeadLetterReplay(c *C) {
+	fixed := int32(0)
+	newBuggyHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &buggyHandler{fixed: &fixed}, nil
+	}
+
+	store := deadletter.NewMemory()
+
+	cv := conveyor.New(10, faces.ChanStdGo, "dead-letter")
+	c.Assert(cv.AddHandler("first", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("buggy", 1, 2, newBuggyHandler), IsNil)
+	c.Assert(cv.AddHandler("last", 1, 1, newPathHandler), IsNil)
+
+	_, err := cv.Replay(context.Background(), nil, "")
+	c.Assert(err, ErrorMatches, "dead-letter store is not set up")
+
+	cv.SetDeadLetterStore(store)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	messages := make([]*pathMessage, 0)
+	for i := 1; i <= 4; i++ {
+		msg := &pathMessage{id: i}
+		messages = append(messages, msg)
+
+		_, err := cv.RunRes(input.New().Data(msg))
+		c.Assert(err, ErrorMatches, "bug")
+	}
+
+	// successful items are not stored
+	_, err = cv.RunRes(input.New().Data(&pathMessage{}).SkipToName("last"))
+	c.Assert(err, IsNil)
+
+	letters, err := store.List(nil)
+	c.Assert(err, IsNil)
+	c.Assert(len(letters), Equals, 4)
+	c.Assert(letters[0].Stage, Equals, faces.Name("buggy"))
+	c.Assert(letters[0].Error, Equals, "bug")
+
+	_, err = cv.Replay(context.Background(), nil, "unknown")
+	c.Assert(err, ErrorMatches, "handler 'unknown' is not found")
+
+	atomic.StoreInt32(&fixed, 1)
+
+	// replay starts at the failed stage
+	n, err := cv.Replay(context.Background(), func(letter *faces.DeadLetter) bool {
+		return letter.Data.(*pathMessage).id%2 == 0
+	}, "")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 2)
+
+	// replay starts at the chosen stage
+	n, err = cv.Replay(context.Background(), nil, "first")
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 2)
+
+	cv.WaitAndStop()
+
+	letters, err = store.List(nil)
+	c.Assert(err, IsNil)
+	c.Assert(len(letters), Equals, 0)
+
+	for _, msg := range messages {
+		if msg.id%2 == 0 {
+			c.Assert(msg.path, DeepEquals, []faces.Name{"first", "buggy", "last"})
+		} else {
+			c.Assert(msg.path, DeepEquals, []faces.Name{"first", "first", "buggy", "last"})
+		}
+	}
+}
+
+func (s *testSuite) TestDeadLetterReplayFull(c *C) {
+	ids, release := make(chan int64, 1), make(chan struct{})
+	newGateHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateHandler{ids: ids, release: release}, nil
+	}
+
+	store := deadletter.NewMemory()
+	c.Assert(store.Save(&faces.DeadLetter{ID: 1, Data: 1}), IsNil)
+	c.Assert(store.Save(&faces.DeadLetter{ID: 2, Data: 2}), IsNil)
+
+	cv := conveyor.New(1, faces.ChanStdGo, "dead-letter-full")
+	c.Assert(cv.AddHandler("gate", 1, 1, newGateHandler), IsNil)
+	cv.SetDeadLetterStore(store)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// the single place of work bench is busy
+	c.Assert(cv.Run(input.New().Data(0)), IsNil)
+	<-ids
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// records which are not sent are saved back
+	n, err := cv.Replay(ctx, nil, "")
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(n, Equals, 0)
+
+	letters, err := store.List(nil)
+	c.Assert(err, IsNil)
+	c.Assert(len(letters), Equals, 2)
+
+	close(release)
+	cv.WaitAndStop()
+
+	_, err = cv.Replay(context.Background(), nil, "")
+	c.Assert(err, Equals, faces.ErrStopping)
+
+	letters, err = store.List(nil)
+	c.Assert(err, IsNil)
+	c.Assert(len(letters), Equals, 2)
+}
+
+func (s *testSuite) TestDeadLetterCancel(c *C) {
+	ids := make(chan int64, 1)
+	newLongHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &longHandler{ids: ids}, nil
+	}
+
+	store := deadletter.NewMemory()
+
+	cv := conveyor.New(10, faces.ChanStdGo, "dead-letter-cancel")
+	c.Assert(cv.AddHandler("long", 1, 1, newLongHandler), IsNil)
+	cv.SetDeadLetterStore(store)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := cv.RunRes(input.New().Data(1))
+		done <- err
+	}()
+
+	c.Assert(cv.Cancel(<-ids, errAbort), Equals, true)
+	c.Assert(<-done, Equals, errAbort)
+
+	cv.WaitAndStop()
+
+	// canceled items are not failed ones
+	letters, err := store.List(nil)
+	c.Assert(err, IsNil)
+	c.Assert(len(letters), Equals, 0)
+}
@@ -0,0 +1,45 @@
+package conveyor
+
+import (
+	"github.com/iostrovok/conveyor/faces"
+)
+
+// matchError checks that error of item is matched by one of matchers.
+func matchError(item faces.IItem, matchers []faces.ErrorMatcher) bool {
+	err, stage := item.GetError(), item.GetHandlerError()
+	for _, match := range matchers {
+		if match(err, stage) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// classifyErrors sets up the selection of items for error handlers by their matchers.
+// Handlers without matchers are the default ones, they get items which are not matched by others.
+func (c *Conveyor) classifyErrors() {
+	if len(c.data.errorMatchers) == 0 {
+		return
+	}
+
+	all := make([]faces.ErrorMatcher, 0)
+	for _, matchers := range c.data.errorMatchers {
+		all = append(all, matchers...)
+	}
+
+	for mg := c.data.firstErrorManager; mg != nil; mg = mg.GetNextManager() {
+		matchers, ok := c.data.errorMatchers[mg.Name()]
+		if !ok {
+			mg.SetMatcher(func(item faces.IItem) bool {
+				return !matchError(item, all)
+			})
+
+			continue
+		}
+
+		mg.SetMatcher(func(item faces.IItem) bool {
+			return matchError(item, matchers)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package conveyor_test
+
+import (
+	"context"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+	"github.com/iostrovok/conveyor/scatter"
+)
+
+var errValidation = errors.New("validation error")
+
+type networkError struct{}
+
+func (e *networkError) Error() string {
+	return "network error"
+}
+
+type classHandler struct {
+	faces.EmptyHandler
+}
+
+func newClassHandler(_ faces.Name) (faces.IHandler, error) {
+	return &classHandler{}, nil
+}
+
+// Run fails the items with different errors by id.
+func (h *classHandler) Run(item faces.IItem) error {
+	switch item.Get().(*pathMessage).id % 4 {
+	case 0:
+		return errors.Wrap(errValidation, "check")
+	case 1:
+		return errors.WithMessage(&networkError{}, "check")
+	case 2:
+		panic("check")
+	}
+
+	return errors.New("unknown error")
+}
+
+func (s *testSuite) TestErrorMatchers(c *C) {
+	var netErr *networkError
+
+	cv := conveyor.New(10, faces.ChanStdGo, "error-matchers")
+	c.Assert(cv.AddHandler("check", 1, 2, newClassHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("validation", 1, 1, newPathHandler, faces.ErrorIs(errValidation)), IsNil)
+	c.Assert(cv.AddErrorHandler("default", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("network", 1, 1, newPathHandler, faces.ErrorAs(&netErr)), IsNil)
+	c.Assert(cv.AddErrorHandler("panics", 1, 1, newPathHandler, faces.IsPanic, faces.ErrorFrom("nobody")), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	expected := []faces.Name{"validation", "network", "panics", "default"}
+	for i := 0; i < 8; i++ {
+		res, err := cv.RunRes(input.New().Data(&pathMessage{id: i}))
+		c.Assert(err, NotNil)
+		c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{expected[i%4]})
+	}
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestErrorMatchersFrom(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "error-matchers")
+	c.Assert(cv.AddHandler("check", 1, 1, newClassHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("first", 1, 1, newPathHandler, faces.ErrorFrom("check")), IsNil)
+	c.Assert(cv.AddErrorHandler("second", 1, 1, newPathHandler, faces.ErrorFrom("check")), IsNil)
+	c.Assert(cv.AddErrorHandler("default", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// all matched handlers process the item
+	res, err := cv.RunRes(input.New().Data(&pathMessage{id: 3}))
+	c.Assert(err, ErrorMatches, "unknown error")
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"first", "second"})
+
+	cv.WaitAndStop()
+}
+
+type mergedHandler struct {
+	faces.EmptyHandler
+}
+
+func newMergedHandler(_ faces.Name) (faces.IHandler, error) {
+	return &mergedHandler{}, nil
+}
+
+// Run fails the item with the merged errors as scatter handler does.
+func (h *mergedHandler) Run(_ faces.IItem) error {
+	return errors.Wrap(scatter.Errors{errors.New("first"), errors.WithMessage(&networkError{}, "second")}, "merged")
+}
+
+type brokenHandler struct {
+	faces.EmptyHandler
+}
+
+func newBrokenHandler(_ faces.Name) (faces.IHandler, error) {
+	return &brokenHandler{}, nil
+}
+
+// Run marks the item and fails itself.
+func (h *brokenHandler) Run(item faces.IItem) error {
+	item.Get().(*pathMessage).add("broken")
+
+	return errors.New("broken error handler")
+}
+
+type handlingHandler struct {
+	faces.EmptyHandler
+
+	errs chan []error
+}
+
+// Run reports the errors of error handlers.
+func (h *handlingHandler) Run(item faces.IItem) error {
+	h.errs <- item.GetHandlingErrors()
+
+	return nil
+}
+
+func (s *testSuite) TestErrorMatchersMerged(c *C) {
+	var netErr *networkError
+
+	cv := conveyor.New(10, faces.ChanStdGo, "error-matchers")
+	c.Assert(cv.AddHandler("merged", 1, 1, newMergedHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("network", 1, 1, newPathHandler, faces.ErrorAs(&netErr)), IsNil)
+	c.Assert(cv.AddErrorHandler("default", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	res, err := cv.RunRes(input.New().Data(&pathMessage{}))
+	c.Assert(err, ErrorMatches, "merged: first; second: network error")
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"network"})
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestErrorMatchersHandlerError(c *C) {
+	errs := make(chan []error, 1)
+	newHandlingHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &handlingHandler{errs: errs}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "error-matchers").SetKeepItemError(true)
+	c.Assert(cv.AddHandler("check", 1, 1, newClassHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("broken", 1, 1, newBrokenHandler, faces.ErrorFrom("check")), IsNil)
+	c.Assert(cv.AddErrorHandler("validation", 1, 1, newPathHandler, faces.ErrorIs(errValidation)), IsNil)
+	c.Assert(cv.AddErrorHandler("report", 1, 1, newHandlingHandler, faces.ErrorFrom("check")), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// the error of error handler doesn't replace the original one
+	res, err := cv.RunRes(input.New().Data(&pathMessage{id: 0}))
+	c.Assert(errors.Is(err, errValidation), Equals, true)
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"broken", "validation"})
+
+	handling := <-errs
+	c.Assert(handling, HasLen, 1)
+	c.Assert(handling[0], ErrorMatches, "broken error handler")
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestErrorMatchersHandlerErrorReplaces(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "error-matchers")
+	c.Assert(cv.AddHandler("check", 1, 1, newClassHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("broken", 1, 1, newBrokenHandler, faces.ErrorFrom("check")), IsNil)
+	c.Assert(cv.AddErrorHandler("validation", 1, 1, newPathHandler, faces.ErrorIs(errValidation)), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// by default the error of error handler replaces the original one
+	res, err := cv.RunRes(input.New().Data(&pathMessage{id: 0}))
+	c.Assert(err, ErrorMatches, "broken error handler")
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"broken"})
+
+	cv.WaitAndStop()
+}
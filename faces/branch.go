@@ -0,0 +1,26 @@
+package faces
+
+// File describes the branch interface.
+
+// Predicate checks the item and returns true if the item should be sent by the route.
+type Predicate func(item IItem) bool
+
+// Route describes the conditional way from the manager to the input channel of another one.
+type Route struct {
+	Name      Name
+	Ch        IChan
+	Predicate Predicate
+}
+
+/*
+IBranch is an interface to build the separate chain of handlers.
+Items which are accepted by the branch predicate leave the main chain
+and are processed by the branch handlers only.
+*/
+type IBranch interface {
+	Name() Name
+
+	// AddHandler adds handler to the end of branch.
+	// The order of adding handlers are important, the same as for IConveyor.AddHandler.
+	AddHandler(manageName Name, minCount, maxCount int, handler GiveBirth) error
+}
@@ -0,0 +1,54 @@
+package faces
+
+import (
+	"time"
+
+	"github.com/iostrovok/conveyor/protobuf/go/nodes"
+)
+
+// File describes the circuit breaker interface.
+
+// BreakerPolicy defines what happens with items while the circuit breaker is open.
+type BreakerPolicy int
+
+const (
+	// BreakerFailFast sends items to the error handlers with ErrCircuitOpen.
+	BreakerFailFast BreakerPolicy = iota
+
+	// BreakerBypass sends items to the next handler without processing.
+	BreakerBypass
+)
+
+// BreakerConfig is a configuration of circuit breaker. Zero values are replaced by defaults.
+type BreakerConfig struct {
+	Window      time.Duration // sliding window of counting errors, 10 seconds by default
+	MinRequests int           // min number of calls in window to open the breaker, 10 by default
+	Threshold   float64       // rate of errors (0, 1] to open the breaker, 0.5 by default
+	Cooldown    time.Duration // time of open state before probing, 5 seconds by default
+	Policy      BreakerPolicy
+}
+
+// ErrCircuitOpen is an error of item which is not processed because the circuit breaker of handler is open.
+type ErrCircuitOpen struct {
+	Name Name
+}
+
+// Error supports the error interface.
+func (e *ErrCircuitOpen) Error() string {
+	return "circuit breaker of '" + string(e.Name) + "' is open"
+}
+
+/*
+IBreaker is an interface of circuit breaker which is shared by all workers of manager.
+The breaker opens when the rate of handler errors crosses the threshold and half-opens after cooldown to probe.
+*/
+type IBreaker interface {
+	// Allow returns true if handler can be called and true if this call is the probe of half-open breaker.
+	Allow() (allowed bool, probe bool)
+
+	// Done reports the result of handler call. The probe flag is the one returned by Allow for this call.
+	Done(err error, probe bool)
+
+	Policy() BreakerPolicy
+	Info() *nodes.BreakerData
+}
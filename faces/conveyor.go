@@ -5,6 +5,7 @@ package faces
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/iostrovok/conveyor/protobuf/go/nodes"
@@ -21,24 +22,45 @@ type IInput interface {
 	Data(data interface{}) IInput       // nil is by default
 	Priority(priority int) IInput       // by default is IConveyor.DefaultPriority()
 	SkipToName(name Name) IInput        // by default is ""
+	Key(key string) IInput              // by default is "", see IKeyOrder
 
 	// return all data above
 	Values() (ctx context.Context, tr ITrace, data interface{}, priority *int, name Name)
 	Ctx() context.Context
+	GetKey() string
 }
 
+// ErrConveyorFull is returned by IConveyor.TryRun if conveyor has no room for new item.
+var ErrConveyorFull = errors.New("conveyor is full")
+
 // IConveyor is interface for support the conveyor.
 type IConveyor interface {
 	Start(ctx context.Context) error
 	Stop()
 	WaitAndStop()
+	Shutdown(ctx context.Context) error
+	State() ConveyorState
 
 	// simple pushing
-	Run(IInput)
+	Run(IInput) error
 	RunRes(IInput) (interface{}, error)
+	RunAsync(IInput) (IFuture, error)
+
+	// pushing without waiting or with waiting until context is done
+	TryRun(IInput) (int64, error)
+	RunContext(ctx context.Context, i IInput) (int64, error)
+
+	// Subscribe returns the channel of items which have passed the final handlers.
+	Subscribe(filter Predicate, buffer int, policy SubscribePolicy) <-chan IItem
+
+	// Cancel stops the item by id, see IItem.Cancel.
+	Cancel(id int64, reason error) bool
+
+	// ItemStatus returns the condition of item by id, see IItem.Status.
+	ItemStatus(id int64) (*ItemStatus, bool)
 
 	// simple pushing in test mode
-	RunTest(i IInput, object ITestObject)
+	RunTest(i IInput, object ITestObject) error
 	RunResTest(i IInput, object ITestObject) (interface{}, error)
 
 	SetDefaultPriority(defaultPriority int)
@@ -48,9 +70,27 @@ type IConveyor interface {
 	GetName() string
 
 	SetWorkersCounter(wc IWorkersCounter) IConveyor
+	SetMaxHops(maxHops int) IConveyor
+	SetMaxResumes(maxResumes int) IConveyor
+	SetKeepItemError(keep bool) IConveyor
+	SetResequencer(window int, timeout time.Duration) IConveyor
+	SetDeadLetterStore(store IDeadLetterStore) IConveyor
+	Replay(ctx context.Context, filter DeadLetterFilter, stage Name) (int, error)
 	AddHandler(manageName Name, minCount, maxCount int, handler GiveBirth) error
-	AddErrorHandler(manageName Name, minCount, maxCount int, handler GiveBirth) error
+	AddBatchHandler(manageName Name, minCount, maxCount, maxBatch int, maxWait time.Duration, handler GiveBirthBatch) error
+	AddScatterHandler(manageName Name, minCount, maxCount int, join JoinFunc, handlers ...GiveBirth) error
+	SetKeyOrder(manageName Name, keyFn KeyFunc) error
+	SetRateLimit(manageName Name, perSecond float64, burst int) error
+	SetBreaker(manageName Name, config BreakerConfig) error
+	SetRetry(manageName Name, policy RetryPolicy) error
+	SetTimeout(manageName Name, timeout time.Duration) error
+	AddWindowHandler(manageName Name, minCount, maxCount int, window, slide time.Duration,
+		keyFn KeyFunc, reducer Reducer, policy WindowPolicy) error
+	AddErrorHandler(manageName Name, minCount, maxCount int, handler GiveBirth, matchers ...ErrorMatcher) error
 	AddFinalHandler(manageName Name, minCount, maxCount int, handler GiveBirth) error
+	AddBranch(name Name, predicate Predicate, rejoin bool) (IBranch, error)
+	AddGraph(graph IGraph) error
+	AddSubConveyor(manageName Name, minCount, maxCount int, inner IConveyor) error
 	Statistic() *nodes.SlaveNodeInfoRequest
 	SetTracer(tr ITrace, duration time.Duration) IConveyor
 
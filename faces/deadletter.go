@@ -0,0 +1,32 @@
+package faces
+
+import "time"
+
+// File describes the dead-letter store interface.
+
+// DeadLetter is a record about item which has left the conveyor with an error.
+type DeadLetter struct {
+	ID    int64       `json:"id"`    // id of failed item, it's changed by replay
+	Data  interface{} `json:"data"`  // payload of item
+	Error string      `json:"error"` // text of item error
+	Stage Name        `json:"stage"` // name of handler which has failed the item, see IItem.GetHandlerError
+	Time  time.Time   `json:"time"`  // time of saving
+}
+
+// DeadLetterFilter selects the records of dead-letter store, nil filter selects all of them.
+type DeadLetterFilter func(letter *DeadLetter) bool
+
+/*
+IDeadLetterStore is an interface to persist the items which have failed all handlers including the error ones.
+Stored items can be replayed by IConveyor.Replay.
+*/
+type IDeadLetterStore interface {
+	// Save adds the record to store.
+	Save(letter *DeadLetter) error
+
+	// List returns the records which are selected by filter.
+	List(filter DeadLetterFilter) ([]*DeadLetter, error)
+
+	// Take removes the records which are selected by filter from store and returns them.
+	Take(filter DeadLetterFilter) ([]*DeadLetter, error)
+}
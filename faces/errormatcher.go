@@ -0,0 +1,91 @@
+package faces
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// File describes the classification of errors for error handlers.
+
+// ErrorMatcher selects the failed items for error handler by error and name of handler which has failed the item,
+// see IItem.GetHandlerError.
+type ErrorMatcher func(err error, stage Name) bool
+
+// ErrPanic is an error of item which handler has panicked.
+type ErrPanic struct {
+	Value interface{}
+}
+
+// Error supports the error interface.
+func (e *ErrPanic) Error() string {
+	return fmt.Sprintf("%+v", e.Value)
+}
+
+// anyError returns true if match is true for err or for one of errors wrapped in it.
+// The merged errors which support Unwrap() []error, like scatter.Errors or errors of split children, are checked too.
+func anyError(err error, match func(err error) bool) bool {
+	for err != nil {
+		if match(err) {
+			return true
+		}
+
+		if merged, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range merged.Unwrap() {
+				if anyError(e, match) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return false
+}
+
+// ErrorIs returns the matcher which selects items with error matched to target by errors.Is.
+// The merged errors are checked one by one.
+func ErrorIs(target error) ErrorMatcher {
+	return func(err error, _ Name) bool {
+		return anyError(err, func(e error) bool {
+			return errors.Is(e, target)
+		})
+	}
+}
+
+// ErrorAs returns the matcher which selects items with error matched to type of target by errors.As.
+// Target is a pointer as for errors.As, it's not changed by matcher. The merged errors are checked one by one.
+func ErrorAs(target interface{}) ErrorMatcher {
+	typ := reflect.TypeOf(target).Elem()
+
+	return func(err error, _ Name) bool {
+		return anyError(err, func(e error) bool {
+			return errors.As(e, reflect.New(typ).Interface())
+		})
+	}
+}
+
+// ErrorFrom returns the matcher which selects items failed by one of handlers.
+func ErrorFrom(stages ...Name) ErrorMatcher {
+	return func(_ error, stage Name) bool {
+		for _, s := range stages {
+			if s == stage {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// IsPanic is a matcher which selects items which handler has panicked.
+func IsPanic(err error, _ Name) bool {
+	return anyError(err, func(err error) bool {
+		var e *ErrPanic
+
+		return errors.As(err, &e)
+	})
+}
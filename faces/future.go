@@ -0,0 +1,18 @@
+package faces
+
+// File describes the result of asynchronous processing.
+
+// IFuture is an interface of the result of item which is sent by IConveyor.RunAsync.
+type IFuture interface {
+	// ID returns the id of item, see IConveyor.Cancel and IConveyor.ItemStatus.
+	ID() int64
+
+	// Done is closed when the result is ready.
+	Done() <-chan struct{}
+
+	// Result waits for the result and returns the data and error of item.
+	Result() (interface{}, error)
+
+	// Cancel stops the item, the result has the cancel error.
+	Cancel()
+}
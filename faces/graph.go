@@ -0,0 +1,34 @@
+package faces
+
+// File describes the graph interface.
+
+// GraphNode describes the single handler of graph.
+type GraphNode struct {
+	Name     Name
+	MinCount int
+	MaxCount int
+	Handler  GiveBirth
+}
+
+// GraphEdge describes the way between two handlers of graph.
+// Edge without predicate is default one, it's used if no other edge accepts the item.
+type GraphEdge struct {
+	From      Name
+	To        Name
+	Predicate Predicate
+}
+
+/*
+IGraph is an interface to define any directed acyclic topology of handlers.
+Graph should have the single entry node. Items go to the final handlers from the nodes without default edge.
+*/
+type IGraph interface {
+	AddNode(name Name, minCount, maxCount int, handler GiveBirth) error
+	AddEdge(from, to Name, predicate Predicate) error
+
+	// Validate checks the graph for cycles and dangling nodes.
+	Validate() error
+
+	Nodes() []GraphNode
+	Edges() []GraphEdge
+}
@@ -23,3 +23,23 @@ type IHandler interface {
 	// Stop() function is called before destruction of handler.
 	Stop(ctx context.Context)
 }
+
+// GiveBirthBatch return new batch handler. Type Name is string which was passed with AddBatchHandler(...).
+type GiveBirthBatch func(name Name) (IBatchHandler, error)
+
+// IBatchHandler is interface for support the handler which processes several items at once.
+type IBatchHandler interface {
+	IHandler
+
+	// RunBatch() function is called for processing the batch of items.
+	// It returns the error for each item in the same order, nil slice means that all items are processed successfully.
+	RunBatch(items []IItem) []error
+}
+
+// IHolder is interface for support the handler which holds items in work bench, see IItem.Hold.
+type IHolder interface {
+	IHandler
+
+	// Held() function is called after Run() for item which is held. Index is a position of item in work bench.
+	Held(index int, item IItem)
+}
@@ -22,23 +22,78 @@ type IItem interface {
 	GetError() error
 	CleanError()
 
+	// AddHandlingError keeps the error of error handler, the error of item and GetHandlerError are not changed by it.
+	// Error handlers use it instead of AddError if IConveyor.SetKeepItemError is turned on.
+	AddHandlingError(err error)
+	GetHandlingErrors() []error
+
 	SetSkipNames(label ...Name)
 	SetSkipToName(label Name)
 	GetSkipToName() Name
 	GetSkipNames() []Name
 	NeedToSkip(worker IWorker) (bool, error)
 
+	// RouteTo sends the item to the named handler after the current one, it may be earlier or later in the chain.
+	// The item goes to the error handlers if the conveyor is stopped.
+	RouteTo(label Name)
+	GetRouteTo() Name
+	// Hop cleans the route target and returns the number of routes which were made for the item.
+	Hop() int
+	GetHops() int
+
+	// Resume sends the item from the error handler back to the named worker handler, the error of item is cleaned.
+	// The item goes to the final handlers with an error if the conveyor is stopped.
+	// Resumed cleans the target and returns the number of resumes which were made for the item.
+	Resume(label Name)
+	GetResume() Name
+	Resumed() int
+	GetResumes() int
+
+	// Split requests the child items with data which are created after the current handler.
+	// The item waits for all children and goes to the final handlers with the list of their results.
+	Split(data ...interface{})
+	TakeSplit() []interface{}
+	SetParent(parent IItem)
+	GetParent() IItem
+	AddChild(child IItem)
+	GetChildren() []IItem
+
+	// SetAttempts sets up the number of the current attempt of processing by handler, see RetryPolicy.
+	SetAttempts(attempts int)
+	GetAttempts() int
+
+	// SetKey sets up the partition key, see IKeyOrder.
+	SetKey(key string)
+	GetKey() string
+
+	// Hold keeps the item in work bench after the current handler.
+	// The handler is responsible for sending item further by itself.
+	Hold()
+	TakeHold() bool
+
 	LogTraceFinishTimef(format string, a ...interface{})
 	LogTracef(format string, a ...interface{})
+	GetTrace() ITrace
+
+	// Snapshot returns the function which restores the current state of item.
+	Snapshot() (restore func())
+	// Detach returns the copy of item and the function which takes the state of copy back to item.
+	// The copy shares the context and tracer with item, the cancel reason of item is kept by apply.
+	Detach() (copy IItem, apply func())
 
 	Start()
 	Cancel()
+	// SetCancelReason sets up the error which the canceled item goes to error handlers with.
+	SetCancelReason(reason error)
+	GetCancelReason() error
 	Finish()
 
 	PushedToChannel(label Name)
 	ReceivedFromChannel()
 	BeforeProcess(label Name)
 	AfterProcess(label Name, err error)
+	// Status returns the snapshot of item condition which is fixed by methods above.
+	Status() *ItemStatus
 
 	// >>>>>>> Priority Queue Supports
 	GetPriority() int
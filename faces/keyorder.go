@@ -0,0 +1,15 @@
+package faces
+
+// File describes the key ordered processing interface.
+
+/*
+IKeyOrder is an interface to process items with the same key one by one and in order of coming.
+Items with different keys are processed by workers in parallel. Items with empty key are not ordered.
+*/
+type IKeyOrder interface {
+	// Key returns the key of item.
+	Key(item IItem) string
+
+	// Done is called by worker when item with the key is processed and sent further.
+	Done(key string)
+}
@@ -59,6 +59,47 @@ type IManager interface {
 	SetChanIn(in IChan) IManager
 	SetChanOut(out IChan) IManager
 	SetChanErr(errCh IChan) IManager
+	GetChanIn() IChan
+
+	// AddRoute adds the conditional way to another manager.
+	// Routes are checked in the order of adding, the first matched one is used.
+	AddRoute(route Route) IManager
+
+	// SetStages sets up the input channels of all worker managers for IItem.RouteTo.
+	SetStages(stages map[Name]IChan, maxHops int) IManager
+
+	// SetMaxResumes sets up the max number of IItem.Resume calls for single item.
+	SetMaxResumes(maxResumes int) IManager
+
+	// SetKeepItemError turns on keeping the errors of error handlers apart from the error of item, see IItem.AddHandlingError.
+	SetKeepItemError(keep bool) IManager
+
+	// SetBatch sets up the batch mode for handlers which support IBatchHandler.
+	SetBatch(maxBatch int, maxWait time.Duration) IManager
+
+	// SetSplitter sets up the creator of child items, see IItem.Split.
+	SetSplitter(splitter ISplitter) IManager
+
+	// SetKeyOrder turns on the processing of items with the same key one by one, see IKeyOrder.
+	SetKeyOrder(keyFn KeyFunc) IManager
+
+	// SetRateLimit sets up the max number of handler calls per second shared by all workers.
+	SetRateLimit(perSecond float64, burst int) IManager
+
+	// SetBreaker sets up the circuit breaker shared by all workers, see IBreaker.
+	SetBreaker(config BreakerConfig) IManager
+
+	// SetRetry sets up the policy of retrying the failed items at the same stage.
+	SetRetry(policy RetryPolicy) IManager
+
+	// SetTimeout sets up the max time of single handler call, see ErrStageTimeout.
+	SetTimeout(timeout time.Duration) IManager
+
+	// SetMatcher sets up the selection of items which are processed by handler, other items go further as is.
+	SetMatcher(matcher func(item IItem) bool) IManager
+
+	// SetOnLeave sets up the function which is called for each item which leaves the conveyor by final handler.
+	SetOnLeave(onLeave func(item IItem)) IManager
 
 	GetNextManager() IManager
 	SetNextManager(next IManager) IManager
@@ -95,6 +136,19 @@ type IWorker interface {
 
 	SetBorderCond(typ ManagerType, isLast bool, nextManagerName Name)
 	GetBorderCond() (Name, ManagerType, bool)
+	SetRoutes(routes []Route)
+	SetStages(stages map[Name]IChan, maxHops int)
+	SetMaxResumes(maxResumes int)
+	SetKeepItemError(keep bool)
+	SetBatch(maxBatch int, maxWait time.Duration)
+	SetSplitter(splitter ISplitter)
+	SetKeyOrder(keys IKeyOrder)
+	SetRateLimiter(limiter IRateLimiter)
+	SetBreaker(breaker IBreaker)
+	SetRetry(policy RetryPolicy)
+	SetTimeout(timeout time.Duration, replace func(worker IWorker))
+	SetMatcher(matcher func(item IItem) bool)
+	SetOnLeave(onLeave func(item IItem))
 
 	Name() Name
 	ID() string
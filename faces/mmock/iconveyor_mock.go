@@ -36,18 +36,52 @@ func (m *MockIConveyor) EXPECT() *MockIConveyorMockRecorder {
 	return m.recorder
 }
 
+// AddBatchHandler mocks base method
+func (m *MockIConveyor) AddBatchHandler(arg0 faces.Name, arg1, arg2, arg3 int, arg4 time.Duration, arg5 faces.GiveBirthBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBatchHandler", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBatchHandler indicates an expected call of AddBatchHandler
+func (mr *MockIConveyorMockRecorder) AddBatchHandler(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBatchHandler", reflect.TypeOf((*MockIConveyor)(nil).AddBatchHandler), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// AddBranch mocks base method
+func (m *MockIConveyor) AddBranch(arg0 faces.Name, arg1 faces.Predicate, arg2 bool) (faces.IBranch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBranch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(faces.IBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBranch indicates an expected call of AddBranch
+func (mr *MockIConveyorMockRecorder) AddBranch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBranch", reflect.TypeOf((*MockIConveyor)(nil).AddBranch), arg0, arg1, arg2)
+}
+
 // AddErrorHandler mocks base method
-func (m *MockIConveyor) AddErrorHandler(arg0 faces.Name, arg1, arg2 int, arg3 faces.GiveBirth) error {
+func (m *MockIConveyor) AddErrorHandler(arg0 faces.Name, arg1, arg2 int, arg3 faces.GiveBirth, arg4 ...faces.ErrorMatcher) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddErrorHandler", arg0, arg1, arg2, arg3)
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddErrorHandler", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddErrorHandler indicates an expected call of AddErrorHandler
-func (mr *MockIConveyorMockRecorder) AddErrorHandler(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockIConveyorMockRecorder) AddErrorHandler(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddErrorHandler", reflect.TypeOf((*MockIConveyor)(nil).AddErrorHandler), arg0, arg1, arg2, arg3)
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddErrorHandler", reflect.TypeOf((*MockIConveyor)(nil).AddErrorHandler), varargs...)
 }
 
 // AddFinalHandler mocks base method
@@ -64,6 +98,20 @@ func (mr *MockIConveyorMockRecorder) AddFinalHandler(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFinalHandler", reflect.TypeOf((*MockIConveyor)(nil).AddFinalHandler), arg0, arg1, arg2, arg3)
 }
 
+// AddGraph mocks base method
+func (m *MockIConveyor) AddGraph(arg0 faces.IGraph) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGraph", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGraph indicates an expected call of AddGraph
+func (mr *MockIConveyorMockRecorder) AddGraph(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGraph", reflect.TypeOf((*MockIConveyor)(nil).AddGraph), arg0)
+}
+
 // AddHandler mocks base method
 func (m *MockIConveyor) AddHandler(arg0 faces.Name, arg1, arg2 int, arg3 faces.GiveBirth) error {
 	m.ctrl.T.Helper()
@@ -78,6 +126,67 @@ func (mr *MockIConveyorMockRecorder) AddHandler(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHandler", reflect.TypeOf((*MockIConveyor)(nil).AddHandler), arg0, arg1, arg2, arg3)
 }
 
+// AddScatterHandler mocks base method
+func (m *MockIConveyor) AddScatterHandler(arg0 faces.Name, arg1, arg2 int, arg3 faces.JoinFunc, arg4 ...faces.GiveBirth) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddScatterHandler", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddScatterHandler indicates an expected call of AddScatterHandler
+func (mr *MockIConveyorMockRecorder) AddScatterHandler(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScatterHandler", reflect.TypeOf((*MockIConveyor)(nil).AddScatterHandler), varargs...)
+}
+
+// AddSubConveyor mocks base method
+func (m *MockIConveyor) AddSubConveyor(arg0 faces.Name, arg1, arg2 int, arg3 faces.IConveyor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubConveyor", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubConveyor indicates an expected call of AddSubConveyor
+func (mr *MockIConveyorMockRecorder) AddSubConveyor(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubConveyor", reflect.TypeOf((*MockIConveyor)(nil).AddSubConveyor), arg0, arg1, arg2, arg3)
+}
+
+// AddWindowHandler mocks base method
+func (m *MockIConveyor) AddWindowHandler(arg0 faces.Name, arg1, arg2 int, arg3, arg4 time.Duration, arg5 faces.KeyFunc, arg6 faces.Reducer, arg7 faces.WindowPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWindowHandler", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddWindowHandler indicates an expected call of AddWindowHandler
+func (mr *MockIConveyorMockRecorder) AddWindowHandler(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWindowHandler", reflect.TypeOf((*MockIConveyor)(nil).AddWindowHandler), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// Cancel mocks base method
+func (m *MockIConveyor) Cancel(arg0 int64, arg1 error) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel
+func (mr *MockIConveyorMockRecorder) Cancel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockIConveyor)(nil).Cancel), arg0, arg1)
+}
+
 // DefaultPriority mocks base method
 func (m *MockIConveyor) DefaultPriority() int {
 	m.ctrl.T.Helper()
@@ -120,6 +229,21 @@ func (mr *MockIConveyorMockRecorder) GetName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockIConveyor)(nil).GetName))
 }
 
+// ItemStatus mocks base method
+func (m *MockIConveyor) ItemStatus(arg0 int64) (*faces.ItemStatus, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ItemStatus", arg0)
+	ret0, _ := ret[0].(*faces.ItemStatus)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ItemStatus indicates an expected call of ItemStatus
+func (mr *MockIConveyorMockRecorder) ItemStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemStatus", reflect.TypeOf((*MockIConveyor)(nil).ItemStatus), arg0)
+}
+
 // MetricPeriod mocks base method
 func (m *MockIConveyor) MetricPeriod(arg0 time.Duration) faces.IConveyor {
 	m.ctrl.T.Helper()
@@ -134,10 +258,27 @@ func (mr *MockIConveyorMockRecorder) MetricPeriod(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetricPeriod", reflect.TypeOf((*MockIConveyor)(nil).MetricPeriod), arg0)
 }
 
+// Replay mocks base method
+func (m *MockIConveyor) Replay(arg0 context.Context, arg1 faces.DeadLetterFilter, arg2 faces.Name) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay
+func (mr *MockIConveyorMockRecorder) Replay(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockIConveyor)(nil).Replay), arg0, arg1, arg2)
+}
+
 // Run mocks base method
-func (m *MockIConveyor) Run(arg0 faces.IInput) {
+func (m *MockIConveyor) Run(arg0 faces.IInput) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Run", arg0)
+	ret := m.ctrl.Call(m, "Run", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Run indicates an expected call of Run
@@ -146,6 +287,36 @@ func (mr *MockIConveyorMockRecorder) Run(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIConveyor)(nil).Run), arg0)
 }
 
+// RunAsync mocks base method
+func (m *MockIConveyor) RunAsync(arg0 faces.IInput) (faces.IFuture, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunAsync", arg0)
+	ret0, _ := ret[0].(faces.IFuture)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunAsync indicates an expected call of RunAsync
+func (mr *MockIConveyorMockRecorder) RunAsync(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunAsync", reflect.TypeOf((*MockIConveyor)(nil).RunAsync), arg0)
+}
+
+// RunContext mocks base method
+func (m *MockIConveyor) RunContext(arg0 context.Context, arg1 faces.IInput) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunContext", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunContext indicates an expected call of RunContext
+func (mr *MockIConveyorMockRecorder) RunContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunContext", reflect.TypeOf((*MockIConveyor)(nil).RunContext), arg0, arg1)
+}
+
 // RunRes mocks base method
 func (m *MockIConveyor) RunRes(arg0 faces.IInput) (interface{}, error) {
 	m.ctrl.T.Helper()
@@ -177,9 +348,11 @@ func (mr *MockIConveyorMockRecorder) RunResTest(arg0, arg1 interface{}) *gomock.
 }
 
 // RunTest mocks base method
-func (m *MockIConveyor) RunTest(arg0 faces.IInput, arg1 faces.ITestObject) {
+func (m *MockIConveyor) RunTest(arg0 faces.IInput, arg1 faces.ITestObject) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RunTest", arg0, arg1)
+	ret := m.ctrl.Call(m, "RunTest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // RunTest indicates an expected call of RunTest
@@ -188,6 +361,34 @@ func (mr *MockIConveyorMockRecorder) RunTest(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTest", reflect.TypeOf((*MockIConveyor)(nil).RunTest), arg0, arg1)
 }
 
+// SetBreaker mocks base method
+func (m *MockIConveyor) SetBreaker(arg0 faces.Name, arg1 faces.BreakerConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBreaker", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBreaker indicates an expected call of SetBreaker
+func (mr *MockIConveyorMockRecorder) SetBreaker(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBreaker", reflect.TypeOf((*MockIConveyor)(nil).SetBreaker), arg0, arg1)
+}
+
+// SetDeadLetterStore mocks base method
+func (m *MockIConveyor) SetDeadLetterStore(arg0 faces.IDeadLetterStore) faces.IConveyor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeadLetterStore", arg0)
+	ret0, _ := ret[0].(faces.IConveyor)
+	return ret0
+}
+
+// SetDeadLetterStore indicates an expected call of SetDeadLetterStore
+func (mr *MockIConveyorMockRecorder) SetDeadLetterStore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeadLetterStore", reflect.TypeOf((*MockIConveyor)(nil).SetDeadLetterStore), arg0)
+}
+
 // SetDefaultPriority mocks base method
 func (m *MockIConveyor) SetDefaultPriority(arg0 int) {
 	m.ctrl.T.Helper()
@@ -200,6 +401,34 @@ func (mr *MockIConveyorMockRecorder) SetDefaultPriority(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultPriority", reflect.TypeOf((*MockIConveyor)(nil).SetDefaultPriority), arg0)
 }
 
+// SetKeepItemError mocks base method
+func (m *MockIConveyor) SetKeepItemError(arg0 bool) faces.IConveyor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeepItemError", arg0)
+	ret0, _ := ret[0].(faces.IConveyor)
+	return ret0
+}
+
+// SetKeepItemError indicates an expected call of SetKeepItemError
+func (mr *MockIConveyorMockRecorder) SetKeepItemError(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeepItemError", reflect.TypeOf((*MockIConveyor)(nil).SetKeepItemError), arg0)
+}
+
+// SetKeyOrder mocks base method
+func (m *MockIConveyor) SetKeyOrder(arg0 faces.Name, arg1 faces.KeyFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeyOrder indicates an expected call of SetKeyOrder
+func (mr *MockIConveyorMockRecorder) SetKeyOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyOrder", reflect.TypeOf((*MockIConveyor)(nil).SetKeyOrder), arg0, arg1)
+}
+
 // SetMasterNode mocks base method
 func (m *MockIConveyor) SetMasterNode(arg0 string, arg1 time.Duration) {
 	m.ctrl.T.Helper()
@@ -212,6 +441,34 @@ func (mr *MockIConveyorMockRecorder) SetMasterNode(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMasterNode", reflect.TypeOf((*MockIConveyor)(nil).SetMasterNode), arg0, arg1)
 }
 
+// SetMaxHops mocks base method
+func (m *MockIConveyor) SetMaxHops(arg0 int) faces.IConveyor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxHops", arg0)
+	ret0, _ := ret[0].(faces.IConveyor)
+	return ret0
+}
+
+// SetMaxHops indicates an expected call of SetMaxHops
+func (mr *MockIConveyorMockRecorder) SetMaxHops(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxHops", reflect.TypeOf((*MockIConveyor)(nil).SetMaxHops), arg0)
+}
+
+// SetMaxResumes mocks base method
+func (m *MockIConveyor) SetMaxResumes(arg0 int) faces.IConveyor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxResumes", arg0)
+	ret0, _ := ret[0].(faces.IConveyor)
+	return ret0
+}
+
+// SetMaxResumes indicates an expected call of SetMaxResumes
+func (mr *MockIConveyorMockRecorder) SetMaxResumes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxResumes", reflect.TypeOf((*MockIConveyor)(nil).SetMaxResumes), arg0)
+}
+
 // SetName mocks base method
 func (m *MockIConveyor) SetName(arg0 string) faces.IConveyor {
 	m.ctrl.T.Helper()
@@ -226,6 +483,62 @@ func (mr *MockIConveyorMockRecorder) SetName(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetName", reflect.TypeOf((*MockIConveyor)(nil).SetName), arg0)
 }
 
+// SetRateLimit mocks base method
+func (m *MockIConveyor) SetRateLimit(arg0 faces.Name, arg1 float64, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRateLimit", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRateLimit indicates an expected call of SetRateLimit
+func (mr *MockIConveyorMockRecorder) SetRateLimit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRateLimit", reflect.TypeOf((*MockIConveyor)(nil).SetRateLimit), arg0, arg1, arg2)
+}
+
+// SetResequencer mocks base method
+func (m *MockIConveyor) SetResequencer(arg0 int, arg1 time.Duration) faces.IConveyor {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetResequencer", arg0, arg1)
+	ret0, _ := ret[0].(faces.IConveyor)
+	return ret0
+}
+
+// SetResequencer indicates an expected call of SetResequencer
+func (mr *MockIConveyorMockRecorder) SetResequencer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResequencer", reflect.TypeOf((*MockIConveyor)(nil).SetResequencer), arg0, arg1)
+}
+
+// SetRetry mocks base method
+func (m *MockIConveyor) SetRetry(arg0 faces.Name, arg1 faces.RetryPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRetry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRetry indicates an expected call of SetRetry
+func (mr *MockIConveyorMockRecorder) SetRetry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRetry", reflect.TypeOf((*MockIConveyor)(nil).SetRetry), arg0, arg1)
+}
+
+// SetTimeout mocks base method
+func (m *MockIConveyor) SetTimeout(arg0 faces.Name, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTimeout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTimeout indicates an expected call of SetTimeout
+func (mr *MockIConveyorMockRecorder) SetTimeout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockIConveyor)(nil).SetTimeout), arg0, arg1)
+}
+
 // SetTracer mocks base method
 func (m *MockIConveyor) SetTracer(arg0 faces.ITrace, arg1 time.Duration) faces.IConveyor {
 	m.ctrl.T.Helper()
@@ -254,6 +567,20 @@ func (mr *MockIConveyorMockRecorder) SetWorkersCounter(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkersCounter", reflect.TypeOf((*MockIConveyor)(nil).SetWorkersCounter), arg0)
 }
 
+// Shutdown mocks base method
+func (m *MockIConveyor) Shutdown(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shutdown", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Shutdown indicates an expected call of Shutdown
+func (mr *MockIConveyorMockRecorder) Shutdown(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockIConveyor)(nil).Shutdown), arg0)
+}
+
 // Start mocks base method
 func (m *MockIConveyor) Start(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -268,6 +595,20 @@ func (mr *MockIConveyorMockRecorder) Start(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIConveyor)(nil).Start), arg0)
 }
 
+// State mocks base method
+func (m *MockIConveyor) State() faces.ConveyorState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "State")
+	ret0, _ := ret[0].(faces.ConveyorState)
+	return ret0
+}
+
+// State indicates an expected call of State
+func (mr *MockIConveyorMockRecorder) State() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "State", reflect.TypeOf((*MockIConveyor)(nil).State))
+}
+
 // Statistic mocks base method
 func (m *MockIConveyor) Statistic() *nodes.SlaveNodeInfoRequest {
 	m.ctrl.T.Helper()
@@ -294,6 +635,35 @@ func (mr *MockIConveyorMockRecorder) Stop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockIConveyor)(nil).Stop))
 }
 
+// Subscribe mocks base method
+func (m *MockIConveyor) Subscribe(arg0 faces.Predicate, arg1 int, arg2 faces.SubscribePolicy) <-chan faces.IItem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan faces.IItem)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockIConveyorMockRecorder) Subscribe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIConveyor)(nil).Subscribe), arg0, arg1, arg2)
+}
+
+// TryRun mocks base method
+func (m *MockIConveyor) TryRun(arg0 faces.IInput) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryRun", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryRun indicates an expected call of TryRun
+func (mr *MockIConveyorMockRecorder) TryRun(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRun", reflect.TypeOf((*MockIConveyor)(nil).TryRun), arg0)
+}
+
 // WaitAndStop mocks base method
 func (m *MockIConveyor) WaitAndStop() {
 	m.ctrl.T.Helper()
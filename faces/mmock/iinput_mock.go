@@ -76,6 +76,34 @@ func (mr *MockIInputMockRecorder) Data(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Data", reflect.TypeOf((*MockIInput)(nil).Data), arg0)
 }
 
+// GetKey mocks base method
+func (m *MockIInput) GetKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetKey indicates an expected call of GetKey
+func (mr *MockIInputMockRecorder) GetKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKey", reflect.TypeOf((*MockIInput)(nil).GetKey))
+}
+
+// Key mocks base method
+func (m *MockIInput) Key(arg0 string) faces.IInput {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Key", arg0)
+	ret0, _ := ret[0].(faces.IInput)
+	return ret0
+}
+
+// Key indicates an expected call of Key
+func (mr *MockIInputMockRecorder) Key(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Key", reflect.TypeOf((*MockIInput)(nil).Key), arg0)
+}
+
 // Priority mocks base method
 func (m *MockIInput) Priority(arg0 int) faces.IInput {
 	m.ctrl.T.Helper()
@@ -34,6 +34,18 @@ func (m *MockIItem) EXPECT() *MockIItemMockRecorder {
 	return m.recorder
 }
 
+// AddChild mocks base method
+func (m *MockIItem) AddChild(arg0 faces.IItem) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddChild", arg0)
+}
+
+// AddChild indicates an expected call of AddChild
+func (mr *MockIItemMockRecorder) AddChild(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChild", reflect.TypeOf((*MockIItem)(nil).AddChild), arg0)
+}
+
 // AddError mocks base method
 func (m *MockIItem) AddError(arg0 error) {
 	m.ctrl.T.Helper()
@@ -46,6 +58,18 @@ func (mr *MockIItemMockRecorder) AddError(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddError", reflect.TypeOf((*MockIItem)(nil).AddError), arg0)
 }
 
+// AddHandlingError mocks base method
+func (m *MockIItem) AddHandlingError(arg0 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddHandlingError", arg0)
+}
+
+// AddHandlingError indicates an expected call of AddHandlingError
+func (mr *MockIItemMockRecorder) AddHandlingError(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHandlingError", reflect.TypeOf((*MockIItem)(nil).AddHandlingError), arg0)
+}
+
 // AfterProcess mocks base method
 func (m *MockIItem) AfterProcess(arg0 faces.Name, arg1 error) {
 	m.ctrl.T.Helper()
@@ -94,6 +118,21 @@ func (mr *MockIItemMockRecorder) CleanError() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanError", reflect.TypeOf((*MockIItem)(nil).CleanError))
 }
 
+// Detach mocks base method
+func (m *MockIItem) Detach() (faces.IItem, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach")
+	ret0, _ := ret[0].(faces.IItem)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Detach indicates an expected call of Detach
+func (mr *MockIItemMockRecorder) Detach() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockIItem)(nil).Detach))
+}
+
 // Finish mocks base method
 func (m *MockIItem) Finish() {
 	m.ctrl.T.Helper()
@@ -120,6 +159,48 @@ func (mr *MockIItemMockRecorder) Get() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIItem)(nil).Get))
 }
 
+// GetAttempts mocks base method
+func (m *MockIItem) GetAttempts() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttempts")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetAttempts indicates an expected call of GetAttempts
+func (mr *MockIItemMockRecorder) GetAttempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttempts", reflect.TypeOf((*MockIItem)(nil).GetAttempts))
+}
+
+// GetCancelReason mocks base method
+func (m *MockIItem) GetCancelReason() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCancelReason")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetCancelReason indicates an expected call of GetCancelReason
+func (mr *MockIItemMockRecorder) GetCancelReason() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCancelReason", reflect.TypeOf((*MockIItem)(nil).GetCancelReason))
+}
+
+// GetChildren mocks base method
+func (m *MockIItem) GetChildren() []faces.IItem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren")
+	ret0, _ := ret[0].([]faces.IItem)
+	return ret0
+}
+
+// GetChildren indicates an expected call of GetChildren
+func (mr *MockIItemMockRecorder) GetChildren() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockIItem)(nil).GetChildren))
+}
+
 // GetContext mocks base method
 func (m *MockIItem) GetContext() context.Context {
 	m.ctrl.T.Helper()
@@ -162,6 +243,34 @@ func (mr *MockIItemMockRecorder) GetHandlerError() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHandlerError", reflect.TypeOf((*MockIItem)(nil).GetHandlerError))
 }
 
+// GetHandlingErrors mocks base method
+func (m *MockIItem) GetHandlingErrors() []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHandlingErrors")
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// GetHandlingErrors indicates an expected call of GetHandlingErrors
+func (mr *MockIItemMockRecorder) GetHandlingErrors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHandlingErrors", reflect.TypeOf((*MockIItem)(nil).GetHandlingErrors))
+}
+
+// GetHops mocks base method
+func (m *MockIItem) GetHops() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHops")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetHops indicates an expected call of GetHops
+func (mr *MockIItemMockRecorder) GetHops() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHops", reflect.TypeOf((*MockIItem)(nil).GetHops))
+}
+
 // GetID mocks base method
 func (m *MockIItem) GetID() int64 {
 	m.ctrl.T.Helper()
@@ -176,6 +285,20 @@ func (mr *MockIItemMockRecorder) GetID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetID", reflect.TypeOf((*MockIItem)(nil).GetID))
 }
 
+// GetKey mocks base method
+func (m *MockIItem) GetKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetKey indicates an expected call of GetKey
+func (mr *MockIItemMockRecorder) GetKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKey", reflect.TypeOf((*MockIItem)(nil).GetKey))
+}
+
 // GetLastHandler mocks base method
 func (m *MockIItem) GetLastHandler() faces.Name {
 	m.ctrl.T.Helper()
@@ -190,6 +313,20 @@ func (mr *MockIItemMockRecorder) GetLastHandler() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastHandler", reflect.TypeOf((*MockIItem)(nil).GetLastHandler))
 }
 
+// GetParent mocks base method
+func (m *MockIItem) GetParent() faces.IItem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParent")
+	ret0, _ := ret[0].(faces.IItem)
+	return ret0
+}
+
+// GetParent indicates an expected call of GetParent
+func (mr *MockIItemMockRecorder) GetParent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParent", reflect.TypeOf((*MockIItem)(nil).GetParent))
+}
+
 // GetPriority mocks base method
 func (m *MockIItem) GetPriority() int {
 	m.ctrl.T.Helper()
@@ -204,6 +341,48 @@ func (mr *MockIItemMockRecorder) GetPriority() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriority", reflect.TypeOf((*MockIItem)(nil).GetPriority))
 }
 
+// GetResume mocks base method
+func (m *MockIItem) GetResume() faces.Name {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResume")
+	ret0, _ := ret[0].(faces.Name)
+	return ret0
+}
+
+// GetResume indicates an expected call of GetResume
+func (mr *MockIItemMockRecorder) GetResume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResume", reflect.TypeOf((*MockIItem)(nil).GetResume))
+}
+
+// GetResumes mocks base method
+func (m *MockIItem) GetResumes() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResumes")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetResumes indicates an expected call of GetResumes
+func (mr *MockIItemMockRecorder) GetResumes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResumes", reflect.TypeOf((*MockIItem)(nil).GetResumes))
+}
+
+// GetRouteTo mocks base method
+func (m *MockIItem) GetRouteTo() faces.Name {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRouteTo")
+	ret0, _ := ret[0].(faces.Name)
+	return ret0
+}
+
+// GetRouteTo indicates an expected call of GetRouteTo
+func (mr *MockIItemMockRecorder) GetRouteTo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouteTo", reflect.TypeOf((*MockIItem)(nil).GetRouteTo))
+}
+
 // GetSkipNames mocks base method
 func (m *MockIItem) GetSkipNames() []faces.Name {
 	m.ctrl.T.Helper()
@@ -246,6 +425,46 @@ func (mr *MockIItemMockRecorder) GetTestObject() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestObject", reflect.TypeOf((*MockIItem)(nil).GetTestObject))
 }
 
+// GetTrace mocks base method
+func (m *MockIItem) GetTrace() faces.ITrace {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrace")
+	ret0, _ := ret[0].(faces.ITrace)
+	return ret0
+}
+
+// GetTrace indicates an expected call of GetTrace
+func (mr *MockIItemMockRecorder) GetTrace() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrace", reflect.TypeOf((*MockIItem)(nil).GetTrace))
+}
+
+// Hold mocks base method
+func (m *MockIItem) Hold() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Hold")
+}
+
+// Hold indicates an expected call of Hold
+func (mr *MockIItemMockRecorder) Hold() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockIItem)(nil).Hold))
+}
+
+// Hop mocks base method
+func (m *MockIItem) Hop() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hop")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Hop indicates an expected call of Hop
+func (mr *MockIItemMockRecorder) Hop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hop", reflect.TypeOf((*MockIItem)(nil).Hop))
+}
+
 // InitEmpty mocks base method
 func (m *MockIItem) InitEmpty() {
 	m.ctrl.T.Helper()
@@ -345,6 +564,44 @@ func (mr *MockIItemMockRecorder) ReceivedFromChannel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivedFromChannel", reflect.TypeOf((*MockIItem)(nil).ReceivedFromChannel))
 }
 
+// Resume mocks base method
+func (m *MockIItem) Resume(arg0 faces.Name) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Resume", arg0)
+}
+
+// Resume indicates an expected call of Resume
+func (mr *MockIItemMockRecorder) Resume(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockIItem)(nil).Resume), arg0)
+}
+
+// Resumed mocks base method
+func (m *MockIItem) Resumed() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resumed")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Resumed indicates an expected call of Resumed
+func (mr *MockIItemMockRecorder) Resumed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resumed", reflect.TypeOf((*MockIItem)(nil).Resumed))
+}
+
+// RouteTo mocks base method
+func (m *MockIItem) RouteTo(arg0 faces.Name) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RouteTo", arg0)
+}
+
+// RouteTo indicates an expected call of RouteTo
+func (mr *MockIItemMockRecorder) RouteTo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RouteTo", reflect.TypeOf((*MockIItem)(nil).RouteTo), arg0)
+}
+
 // Set mocks base method
 func (m *MockIItem) Set(arg0 interface{}) {
 	m.ctrl.T.Helper()
@@ -357,6 +614,30 @@ func (mr *MockIItemMockRecorder) Set(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockIItem)(nil).Set), arg0)
 }
 
+// SetAttempts mocks base method
+func (m *MockIItem) SetAttempts(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAttempts", arg0)
+}
+
+// SetAttempts indicates an expected call of SetAttempts
+func (mr *MockIItemMockRecorder) SetAttempts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttempts", reflect.TypeOf((*MockIItem)(nil).SetAttempts), arg0)
+}
+
+// SetCancelReason mocks base method
+func (m *MockIItem) SetCancelReason(arg0 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCancelReason", arg0)
+}
+
+// SetCancelReason indicates an expected call of SetCancelReason
+func (mr *MockIItemMockRecorder) SetCancelReason(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCancelReason", reflect.TypeOf((*MockIItem)(nil).SetCancelReason), arg0)
+}
+
 // SetHandlerError mocks base method
 func (m *MockIItem) SetHandlerError(arg0 faces.Name) {
 	m.ctrl.T.Helper()
@@ -381,6 +662,18 @@ func (mr *MockIItemMockRecorder) SetID(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetID", reflect.TypeOf((*MockIItem)(nil).SetID), arg0)
 }
 
+// SetKey mocks base method
+func (m *MockIItem) SetKey(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetKey", arg0)
+}
+
+// SetKey indicates an expected call of SetKey
+func (mr *MockIItemMockRecorder) SetKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKey", reflect.TypeOf((*MockIItem)(nil).SetKey), arg0)
+}
+
 // SetLastHandler mocks base method
 func (m *MockIItem) SetLastHandler(arg0 faces.Name) {
 	m.ctrl.T.Helper()
@@ -405,6 +698,18 @@ func (mr *MockIItemMockRecorder) SetLock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLock", reflect.TypeOf((*MockIItem)(nil).SetLock))
 }
 
+// SetParent mocks base method
+func (m *MockIItem) SetParent(arg0 faces.IItem) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetParent", arg0)
+}
+
+// SetParent indicates an expected call of SetParent
+func (mr *MockIItemMockRecorder) SetParent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParent", reflect.TypeOf((*MockIItem)(nil).SetParent), arg0)
+}
+
 // SetPriority mocks base method
 func (m *MockIItem) SetPriority(arg0 int) {
 	m.ctrl.T.Helper()
@@ -469,6 +774,36 @@ func (mr *MockIItemMockRecorder) SetUnlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUnlock", reflect.TypeOf((*MockIItem)(nil).SetUnlock))
 }
 
+// Snapshot mocks base method
+func (m *MockIItem) Snapshot() func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot
+func (mr *MockIItemMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockIItem)(nil).Snapshot))
+}
+
+// Split mocks base method
+func (m *MockIItem) Split(arg0 ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Split", varargs...)
+}
+
+// Split indicates an expected call of Split
+func (mr *MockIItemMockRecorder) Split(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Split", reflect.TypeOf((*MockIItem)(nil).Split), arg0...)
+}
+
 // Start mocks base method
 func (m *MockIItem) Start() {
 	m.ctrl.T.Helper()
@@ -481,6 +816,20 @@ func (mr *MockIItemMockRecorder) Start() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIItem)(nil).Start))
 }
 
+// Status mocks base method
+func (m *MockIItem) Status() *faces.ItemStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(*faces.ItemStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status
+func (mr *MockIItemMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockIItem)(nil).Status))
+}
+
 // Stopped mocks base method
 func (m *MockIItem) Stopped() {
 	m.ctrl.T.Helper()
@@ -492,3 +841,31 @@ func (mr *MockIItemMockRecorder) Stopped() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stopped", reflect.TypeOf((*MockIItem)(nil).Stopped))
 }
+
+// TakeHold mocks base method
+func (m *MockIItem) TakeHold() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeHold")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TakeHold indicates an expected call of TakeHold
+func (mr *MockIItemMockRecorder) TakeHold() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeHold", reflect.TypeOf((*MockIItem)(nil).TakeHold))
+}
+
+// TakeSplit mocks base method
+func (m *MockIItem) TakeSplit() []interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSplit")
+	ret0, _ := ret[0].([]interface{})
+	return ret0
+}
+
+// TakeSplit indicates an expected call of TakeSplit
+func (mr *MockIItemMockRecorder) TakeSplit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSplit", reflect.TypeOf((*MockIItem)(nil).TakeSplit))
+}
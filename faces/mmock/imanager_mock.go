@@ -37,6 +37,34 @@ func (m *MockIManager) EXPECT() *MockIManagerMockRecorder {
 	return m.recorder
 }
 
+// AddRoute mocks base method
+func (m *MockIManager) AddRoute(arg0 faces.Route) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoute", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// AddRoute indicates an expected call of AddRoute
+func (mr *MockIManagerMockRecorder) AddRoute(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoute", reflect.TypeOf((*MockIManager)(nil).AddRoute), arg0)
+}
+
+// GetChanIn mocks base method
+func (m *MockIManager) GetChanIn() faces.IChan {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanIn")
+	ret0, _ := ret[0].(faces.IChan)
+	return ret0
+}
+
+// GetChanIn indicates an expected call of GetChanIn
+func (mr *MockIManagerMockRecorder) GetChanIn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanIn", reflect.TypeOf((*MockIManager)(nil).GetChanIn))
+}
+
 // GetNextManager mocks base method
 func (m *MockIManager) GetNextManager() faces.IManager {
 	m.ctrl.T.Helper()
@@ -107,6 +135,34 @@ func (mr *MockIManagerMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockIManager)(nil).Name))
 }
 
+// SetBatch mocks base method
+func (m *MockIManager) SetBatch(arg0 int, arg1 time.Duration) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBatch", arg0, arg1)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetBatch indicates an expected call of SetBatch
+func (mr *MockIManagerMockRecorder) SetBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatch", reflect.TypeOf((*MockIManager)(nil).SetBatch), arg0, arg1)
+}
+
+// SetBreaker mocks base method
+func (m *MockIManager) SetBreaker(arg0 faces.BreakerConfig) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBreaker", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetBreaker indicates an expected call of SetBreaker
+func (mr *MockIManagerMockRecorder) SetBreaker(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBreaker", reflect.TypeOf((*MockIManager)(nil).SetBreaker), arg0)
+}
+
 // SetChanErr mocks base method
 func (m *MockIManager) SetChanErr(arg0 faces.IChan) faces.IManager {
 	m.ctrl.T.Helper()
@@ -177,6 +233,62 @@ func (mr *MockIManagerMockRecorder) SetIsLast(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsLast", reflect.TypeOf((*MockIManager)(nil).SetIsLast), arg0)
 }
 
+// SetKeepItemError mocks base method
+func (m *MockIManager) SetKeepItemError(arg0 bool) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeepItemError", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetKeepItemError indicates an expected call of SetKeepItemError
+func (mr *MockIManagerMockRecorder) SetKeepItemError(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeepItemError", reflect.TypeOf((*MockIManager)(nil).SetKeepItemError), arg0)
+}
+
+// SetKeyOrder mocks base method
+func (m *MockIManager) SetKeyOrder(arg0 faces.KeyFunc) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyOrder", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetKeyOrder indicates an expected call of SetKeyOrder
+func (mr *MockIManagerMockRecorder) SetKeyOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyOrder", reflect.TypeOf((*MockIManager)(nil).SetKeyOrder), arg0)
+}
+
+// SetMatcher mocks base method
+func (m *MockIManager) SetMatcher(arg0 func(faces.IItem) bool) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMatcher", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetMatcher indicates an expected call of SetMatcher
+func (mr *MockIManagerMockRecorder) SetMatcher(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMatcher", reflect.TypeOf((*MockIManager)(nil).SetMatcher), arg0)
+}
+
+// SetMaxResumes mocks base method
+func (m *MockIManager) SetMaxResumes(arg0 int) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxResumes", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetMaxResumes indicates an expected call of SetMaxResumes
+func (mr *MockIManagerMockRecorder) SetMaxResumes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxResumes", reflect.TypeOf((*MockIManager)(nil).SetMaxResumes), arg0)
+}
+
 // SetNextManager mocks base method
 func (m *MockIManager) SetNextManager(arg0 faces.IManager) faces.IManager {
 	m.ctrl.T.Helper()
@@ -191,6 +303,20 @@ func (mr *MockIManagerMockRecorder) SetNextManager(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNextManager", reflect.TypeOf((*MockIManager)(nil).SetNextManager), arg0)
 }
 
+// SetOnLeave mocks base method
+func (m *MockIManager) SetOnLeave(arg0 func(faces.IItem)) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOnLeave", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetOnLeave indicates an expected call of SetOnLeave
+func (mr *MockIManagerMockRecorder) SetOnLeave(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOnLeave", reflect.TypeOf((*MockIManager)(nil).SetOnLeave), arg0)
+}
+
 // SetPrevManager mocks base method
 func (m *MockIManager) SetPrevManager(arg0 faces.IManager) faces.IManager {
 	m.ctrl.T.Helper()
@@ -205,6 +331,62 @@ func (mr *MockIManagerMockRecorder) SetPrevManager(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrevManager", reflect.TypeOf((*MockIManager)(nil).SetPrevManager), arg0)
 }
 
+// SetRateLimit mocks base method
+func (m *MockIManager) SetRateLimit(arg0 float64, arg1 int) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRateLimit", arg0, arg1)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetRateLimit indicates an expected call of SetRateLimit
+func (mr *MockIManagerMockRecorder) SetRateLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRateLimit", reflect.TypeOf((*MockIManager)(nil).SetRateLimit), arg0, arg1)
+}
+
+// SetRetry mocks base method
+func (m *MockIManager) SetRetry(arg0 faces.RetryPolicy) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRetry", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetRetry indicates an expected call of SetRetry
+func (mr *MockIManagerMockRecorder) SetRetry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRetry", reflect.TypeOf((*MockIManager)(nil).SetRetry), arg0)
+}
+
+// SetSplitter mocks base method
+func (m *MockIManager) SetSplitter(arg0 faces.ISplitter) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSplitter", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetSplitter indicates an expected call of SetSplitter
+func (mr *MockIManagerMockRecorder) SetSplitter(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSplitter", reflect.TypeOf((*MockIManager)(nil).SetSplitter), arg0)
+}
+
+// SetStages mocks base method
+func (m *MockIManager) SetStages(arg0 map[faces.Name]faces.IChan, arg1 int) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStages", arg0, arg1)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetStages indicates an expected call of SetStages
+func (mr *MockIManagerMockRecorder) SetStages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStages", reflect.TypeOf((*MockIManager)(nil).SetStages), arg0, arg1)
+}
+
 // SetTestMode mocks base method
 func (m *MockIManager) SetTestMode(arg0 faces.ITestObject) faces.IManager {
 	m.ctrl.T.Helper()
@@ -219,6 +401,20 @@ func (mr *MockIManagerMockRecorder) SetTestMode(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTestMode", reflect.TypeOf((*MockIManager)(nil).SetTestMode), arg0)
 }
 
+// SetTimeout mocks base method
+func (m *MockIManager) SetTimeout(arg0 time.Duration) faces.IManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTimeout", arg0)
+	ret0, _ := ret[0].(faces.IManager)
+	return ret0
+}
+
+// SetTimeout indicates an expected call of SetTimeout
+func (mr *MockIManagerMockRecorder) SetTimeout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockIManager)(nil).SetTimeout), arg0)
+}
+
 // SetWaitGroup mocks base method
 func (m *MockIManager) SetWaitGroup(arg0 *sync.WaitGroup) faces.IManager {
 	m.ctrl.T.Helper()
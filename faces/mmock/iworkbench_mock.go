@@ -5,6 +5,7 @@
 package mmock
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	faces "github.com/iostrovok/conveyor/faces"
 	reflect "reflect"
@@ -47,6 +48,35 @@ func (mr *MockIWorkBenchMockRecorder) Add(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIWorkBench)(nil).Add), arg0)
 }
 
+// AddContext mocks base method
+func (m *MockIWorkBench) AddContext(arg0 context.Context, arg1 faces.IItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContext", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddContext indicates an expected call of AddContext
+func (mr *MockIWorkBenchMockRecorder) AddContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContext", reflect.TypeOf((*MockIWorkBench)(nil).AddContext), arg0, arg1)
+}
+
+// Changed mocks base method
+func (m *MockIWorkBench) Changed() <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Changed")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Changed indicates an expected call of Changed
+func (mr *MockIWorkBenchMockRecorder) Changed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Changed", reflect.TypeOf((*MockIWorkBench)(nil).Changed))
+}
+
 // Clean mocks base method
 func (m *MockIWorkBench) Clean(arg0 int) {
 	m.ctrl.T.Helper()
@@ -73,6 +103,21 @@ func (mr *MockIWorkBenchMockRecorder) Count() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockIWorkBench)(nil).Count))
 }
 
+// Find mocks base method
+func (m *MockIWorkBench) Find(arg0 int64) (faces.IItem, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", arg0)
+	ret0, _ := ret[0].(faces.IItem)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find
+func (mr *MockIWorkBenchMockRecorder) Find(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIWorkBench)(nil).Find), arg0)
+}
+
 // Get mocks base method
 func (m *MockIWorkBench) Get(arg0 int) (faces.IItem, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +147,20 @@ func (mr *MockIWorkBenchMockRecorder) GetPriority(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriority", reflect.TypeOf((*MockIWorkBench)(nil).GetPriority), arg0)
 }
 
+// Items mocks base method
+func (m *MockIWorkBench) Items() []faces.IItem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Items")
+	ret0, _ := ret[0].([]faces.IItem)
+	return ret0
+}
+
+// Items indicates an expected call of Items
+func (mr *MockIWorkBenchMockRecorder) Items() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Items", reflect.TypeOf((*MockIWorkBench)(nil).Items))
+}
+
 // Len mocks base method
 func (m *MockIWorkBench) Len() int {
 	m.ctrl.T.Helper()
@@ -115,3 +174,18 @@ func (mr *MockIWorkBenchMockRecorder) Len() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*MockIWorkBench)(nil).Len))
 }
+
+// TryAdd mocks base method
+func (m *MockIWorkBench) TryAdd(arg0 faces.IItem) (int, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryAdd", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// TryAdd indicates an expected call of TryAdd
+func (mr *MockIWorkBenchMockRecorder) TryAdd(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryAdd", reflect.TypeOf((*MockIWorkBench)(nil).TryAdd), arg0)
+}
@@ -9,6 +9,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	faces "github.com/iostrovok/conveyor/faces"
 	reflect "reflect"
+	time "time"
 )
 
 // MockIWorker is a mock of IWorker interface
@@ -78,6 +79,18 @@ func (mr *MockIWorkerMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockIWorker)(nil).Name))
 }
 
+// SetBatch mocks base method
+func (m *MockIWorker) SetBatch(arg0 int, arg1 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBatch", arg0, arg1)
+}
+
+// SetBatch indicates an expected call of SetBatch
+func (mr *MockIWorkerMockRecorder) SetBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBatch", reflect.TypeOf((*MockIWorker)(nil).SetBatch), arg0, arg1)
+}
+
 // SetBorderCond mocks base method
 func (m *MockIWorker) SetBorderCond(arg0 faces.ManagerType, arg1 bool, arg2 faces.Name) {
 	m.ctrl.T.Helper()
@@ -90,6 +103,138 @@ func (mr *MockIWorkerMockRecorder) SetBorderCond(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBorderCond", reflect.TypeOf((*MockIWorker)(nil).SetBorderCond), arg0, arg1, arg2)
 }
 
+// SetBreaker mocks base method
+func (m *MockIWorker) SetBreaker(arg0 faces.IBreaker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBreaker", arg0)
+}
+
+// SetBreaker indicates an expected call of SetBreaker
+func (mr *MockIWorkerMockRecorder) SetBreaker(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBreaker", reflect.TypeOf((*MockIWorker)(nil).SetBreaker), arg0)
+}
+
+// SetKeepItemError mocks base method
+func (m *MockIWorker) SetKeepItemError(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetKeepItemError", arg0)
+}
+
+// SetKeepItemError indicates an expected call of SetKeepItemError
+func (mr *MockIWorkerMockRecorder) SetKeepItemError(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeepItemError", reflect.TypeOf((*MockIWorker)(nil).SetKeepItemError), arg0)
+}
+
+// SetKeyOrder mocks base method
+func (m *MockIWorker) SetKeyOrder(arg0 faces.IKeyOrder) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetKeyOrder", arg0)
+}
+
+// SetKeyOrder indicates an expected call of SetKeyOrder
+func (mr *MockIWorkerMockRecorder) SetKeyOrder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyOrder", reflect.TypeOf((*MockIWorker)(nil).SetKeyOrder), arg0)
+}
+
+// SetMatcher mocks base method
+func (m *MockIWorker) SetMatcher(arg0 func(faces.IItem) bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMatcher", arg0)
+}
+
+// SetMatcher indicates an expected call of SetMatcher
+func (mr *MockIWorkerMockRecorder) SetMatcher(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMatcher", reflect.TypeOf((*MockIWorker)(nil).SetMatcher), arg0)
+}
+
+// SetMaxResumes mocks base method
+func (m *MockIWorker) SetMaxResumes(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMaxResumes", arg0)
+}
+
+// SetMaxResumes indicates an expected call of SetMaxResumes
+func (mr *MockIWorkerMockRecorder) SetMaxResumes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxResumes", reflect.TypeOf((*MockIWorker)(nil).SetMaxResumes), arg0)
+}
+
+// SetOnLeave mocks base method
+func (m *MockIWorker) SetOnLeave(arg0 func(faces.IItem)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOnLeave", arg0)
+}
+
+// SetOnLeave indicates an expected call of SetOnLeave
+func (mr *MockIWorkerMockRecorder) SetOnLeave(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOnLeave", reflect.TypeOf((*MockIWorker)(nil).SetOnLeave), arg0)
+}
+
+// SetRateLimiter mocks base method
+func (m *MockIWorker) SetRateLimiter(arg0 faces.IRateLimiter) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRateLimiter", arg0)
+}
+
+// SetRateLimiter indicates an expected call of SetRateLimiter
+func (mr *MockIWorkerMockRecorder) SetRateLimiter(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRateLimiter", reflect.TypeOf((*MockIWorker)(nil).SetRateLimiter), arg0)
+}
+
+// SetRetry mocks base method
+func (m *MockIWorker) SetRetry(arg0 faces.RetryPolicy) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRetry", arg0)
+}
+
+// SetRetry indicates an expected call of SetRetry
+func (mr *MockIWorkerMockRecorder) SetRetry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRetry", reflect.TypeOf((*MockIWorker)(nil).SetRetry), arg0)
+}
+
+// SetRoutes mocks base method
+func (m *MockIWorker) SetRoutes(arg0 []faces.Route) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRoutes", arg0)
+}
+
+// SetRoutes indicates an expected call of SetRoutes
+func (mr *MockIWorkerMockRecorder) SetRoutes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoutes", reflect.TypeOf((*MockIWorker)(nil).SetRoutes), arg0)
+}
+
+// SetSplitter mocks base method
+func (m *MockIWorker) SetSplitter(arg0 faces.ISplitter) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSplitter", arg0)
+}
+
+// SetSplitter indicates an expected call of SetSplitter
+func (mr *MockIWorkerMockRecorder) SetSplitter(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSplitter", reflect.TypeOf((*MockIWorker)(nil).SetSplitter), arg0)
+}
+
+// SetStages mocks base method
+func (m *MockIWorker) SetStages(arg0 map[faces.Name]faces.IChan, arg1 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStages", arg0, arg1)
+}
+
+// SetStages indicates an expected call of SetStages
+func (mr *MockIWorkerMockRecorder) SetStages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStages", reflect.TypeOf((*MockIWorker)(nil).SetStages), arg0, arg1)
+}
+
 // SetTestMode mocks base method
 func (m *MockIWorker) SetTestMode(arg0 faces.ITestObject) {
 	m.ctrl.T.Helper()
@@ -102,6 +247,18 @@ func (mr *MockIWorkerMockRecorder) SetTestMode(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTestMode", reflect.TypeOf((*MockIWorker)(nil).SetTestMode), arg0)
 }
 
+// SetTimeout mocks base method
+func (m *MockIWorker) SetTimeout(arg0 time.Duration, arg1 func(faces.IWorker)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTimeout", arg0, arg1)
+}
+
+// SetTimeout indicates an expected call of SetTimeout
+func (mr *MockIWorkerMockRecorder) SetTimeout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeout", reflect.TypeOf((*MockIWorker)(nil).SetTimeout), arg0, arg1)
+}
+
 // Start mocks base method
 func (m *MockIWorker) Start(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,11 @@
+package faces
+
+import "context"
+
+// File describes the rate limit interface.
+
+// IRateLimiter is an interface to limit the frequency of handler calls. It's shared by all workers of manager.
+type IRateLimiter interface {
+	// Wait blocks until the call is allowed or context is done.
+	Wait(ctx context.Context) error
+}
@@ -0,0 +1,18 @@
+package faces
+
+import "time"
+
+// File describes the retry policy.
+
+// RetryPolicy defines how the item is retried at the same stage if handler returns an error.
+// Only items which exhaust their attempts go to the error handlers.
+type RetryPolicy struct {
+	MaxAttempts int           // total number of handler calls including the first one, 1 or less means no retries
+	Backoff     time.Duration // delay before the second attempt, it's doubled for each next one
+	MaxBackoff  time.Duration // upper limit of delay, zero means no limit
+	Jitter      float64       // random part of delay [0, 1], the delay is reduced by random part
+	Retryable   Retryable     // nil means all errors are retryable
+}
+
+// Retryable checks that item can be retried after the error.
+type Retryable func(err error) bool
@@ -0,0 +1,7 @@
+package faces
+
+// File describes the joining of scatter handlers results.
+
+// JoinFunc joins the results of scatter handlers into the new data of item. Data is the data of item before the stage,
+// results are the data set by each handler with IItem.Set (or data if handler doesn't set it) in order of handlers.
+type JoinFunc func(data interface{}, results []interface{}) interface{}
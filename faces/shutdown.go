@@ -0,0 +1,22 @@
+package faces
+
+import "strconv"
+
+// File describes the report of conveyor shutdown.
+
+// ErrShutdownTimeout is returned by IConveyor.Shutdown if items are not finished before the context is done.
+// The unfinished items are canceled, they go to the error and final handlers.
+type ErrShutdownTimeout struct {
+	Cause      error         // the context error
+	Unfinished []*ItemStatus // the items at the moment of the context is done
+}
+
+// Error supports the error interface.
+func (e *ErrShutdownTimeout) Error() string {
+	return "conveyor is shut down with " + strconv.Itoa(len(e.Unfinished)) + " unfinished items: " + e.Cause.Error()
+}
+
+// Unwrap returns the context error.
+func (e *ErrShutdownTimeout) Unwrap() error {
+	return e.Cause
+}
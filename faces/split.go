@@ -0,0 +1,21 @@
+package faces
+
+// File describes the split interface.
+
+// HoldName is constant for item which is kept in work bench by stage, see IItem.Hold.
+// The split parent is kept until the last child is finished.
+const HoldName Name = "###HOLD_IN_WORK_BENCH"
+
+/*
+ISplitter is an interface to create the child items from one parent.
+The parent waits for all its children and goes to the final handlers after the last of them.
+*/
+type ISplitter interface {
+	// Split creates the child items from data, puts them to the work bench and returns their indexes.
+	// The index is the position of parent in the work bench.
+	// It doesn't wait for the free places, ErrConveyorFull is returned if work bench has no room for all children.
+	Split(index int, parent IItem, data []interface{}) ([]int, error)
+
+	// Done is called when child item leaves the conveyor.
+	Done(child IItem)
+}
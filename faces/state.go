@@ -0,0 +1,26 @@
+package faces
+
+import "errors"
+
+// File describes the lifecycle of conveyor.
+
+// ConveyorState is a lifecycle state of conveyor.
+type ConveyorState string
+
+const (
+	// ConveyorCreated means that conveyor is not started yet, handlers may be added.
+	ConveyorCreated ConveyorState = "created"
+	// ConveyorStarted means that conveyor takes and processes items.
+	ConveyorStarted ConveyorState = "started"
+	// ConveyorDraining means that conveyor doesn't take new items and finishes the taken ones.
+	ConveyorDraining ConveyorState = "draining"
+	// ConveyorStopped means that conveyor is stopped.
+	ConveyorStopped ConveyorState = "stopped"
+)
+
+var (
+	// ErrNotStarted is returned by submission methods of IConveyor before IConveyor.Start.
+	ErrNotStarted = errors.New("conveyor is not started")
+	// ErrStopping is returned by submission methods of IConveyor after IConveyor.Stop or IConveyor.WaitAndStop.
+	ErrStopping = errors.New("conveyor is stopping")
+)
@@ -0,0 +1,39 @@
+package faces
+
+import "time"
+
+// File describes the status of item in conveyor.
+
+// ItemState is a state of item at the current stage.
+type ItemState string
+
+const (
+	// ItemQueued means that item is waiting in channel of handler.
+	ItemQueued ItemState = "queued"
+	// ItemProcessing means that item is being processed by handler.
+	ItemProcessing ItemState = "processing"
+	// ItemWaiting means that item is processed by handler and waits to go further,
+	// e.g. it's held by handler or waits for its children.
+	ItemWaiting ItemState = "waiting"
+)
+
+// StageTime is a time which item has spent at the stage.
+type StageTime struct {
+	Name      Name
+	Queued    time.Duration // in channel of handler
+	Processed time.Duration // in handler
+}
+
+// ItemStatus is a snapshot of item condition in conveyor.
+type ItemStatus struct {
+	ID       int64
+	Stage    Name      // the current stage
+	State    ItemState // the state at the current stage
+	Stages   []StageTime
+	Priority int
+	Error    error
+
+	SkipToName Name
+	SkipNames  []Name
+	Stopped    bool
+}
@@ -0,0 +1,14 @@
+package faces
+
+// File describes the subscription to processed items.
+
+// SubscribePolicy defines what subscription does when its buffer is full.
+type SubscribePolicy int
+
+const (
+	// SubscribeBlock waits until subscriber reads the channel, the final handlers wait too.
+	SubscribeBlock SubscribePolicy = iota
+
+	// SubscribeDrop skips the item which subscriber has no room for.
+	SubscribeDrop
+)
@@ -19,6 +19,10 @@ import (
 	StopTestWithDBConnection(..)
 	StopTestWithMocks(..)
 
+	Batch handlers have the run methods with RunBatchTest prefix:
+
+	RunBatchTestWithMocks(items []IItem, c *check.C) []error
+
 	also the regular methods should be defined:
 	Start(..)
 	Run(..)
@@ -34,6 +38,8 @@ const (
 	StopTestHandlerPrefix = "StopTest"
 	// RunTestHandlerPrefix is a prefix for tests stop method.
 	RunTestHandlerPrefix = "RunTest"
+	// RunBatchTestHandlerPrefix is a prefix for tests run method of batch handler.
+	RunBatchTestHandlerPrefix = "RunBatchTest"
 )
 
 // ITestObject is an interface to use conveyor in setup and troubleshooting mode.
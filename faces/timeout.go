@@ -0,0 +1,15 @@
+package faces
+
+import "time"
+
+// ErrStageTimeout is an error of item which is not processed by handler in the time limit of stage.
+// The item context is not cancelled, the item goes to the error handlers.
+type ErrStageTimeout struct {
+	Name    Name
+	Timeout time.Duration
+}
+
+// Error supports the error interface.
+func (e *ErrStageTimeout) Error() string {
+	return "handler '" + string(e.Name) + "' is timed out after " + e.Timeout.String()
+}
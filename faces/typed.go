@@ -0,0 +1,15 @@
+package faces
+
+// File describes the errors of typed conveyor.
+
+// ErrTypeMismatch is an error of item which data has unexpected type.
+type ErrTypeMismatch struct {
+	Name Name
+	Want string
+	Got  string
+}
+
+// Error supports the error interface.
+func (e *ErrTypeMismatch) Error() string {
+	return "handler '" + string(e.Name) + "' wants data of type " + e.Want + ", got " + e.Got
+}
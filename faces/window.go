@@ -0,0 +1,31 @@
+package faces
+
+import "time"
+
+// File describes the window aggregation types.
+
+// KeyFunc returns the key of item which is used to group items.
+type KeyFunc func(item IItem) string
+
+// Window is a group of items with the same key which are come to stage during the period [Start, End).
+type Window struct {
+	Key    string
+	Start  time.Time
+	End    time.Time
+	Values []interface{}
+}
+
+// Reducer makes the data of aggregate item from closed window.
+type Reducer func(window Window) interface{}
+
+// WindowPolicy defines what happens with items after they are added to window.
+type WindowPolicy int
+
+const (
+	// WindowComplete sends items to the final handlers right after they are added to window.
+	WindowComplete WindowPolicy = iota
+
+	// WindowAbsorb keeps items in work bench until the last window with them is closed.
+	// Items go to the final handlers when the window is closed.
+	WindowAbsorb
+)
@@ -1,17 +1,29 @@
 package faces
 
+import "context"
+
 // IWorkBench is interface for support the storage for IItem.
 type IWorkBench interface {
 	// Set puts new IItem by number in WorkBench
 	Add(item IItem) int
+	// TryAdd puts new IItem in WorkBench if it has a free place
+	TryAdd(item IItem) (int, bool)
+	// AddContext puts new IItem in WorkBench, it waits for a free place while context is not done
+	AddContext(ctx context.Context, item IItem) (int, error)
 	// Get returns item by number in WorkBench
 	Get(i int) (IItem, error)
 	// Len returns the total length of WorkBench
 	Len() int
 	// Count returns the number of active IItem in WorkBench
 	Count() int
+	// Changed returns the channel which is closed when the next active IItem is removed by Clean
+	Changed() <-chan struct{}
 	// Clean removes IItem from WorkBench (makes no-active)
 	Clean(i int)
 	// GetPriority returns the priority for item by number. If item is not fund, return 0.
 	GetPriority(i int) int
+	// Find returns active IItem by its id
+	Find(id int64) (IItem, bool)
+	// Items returns all active IItem
+	Items() []IItem
 }
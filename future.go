@@ -0,0 +1,89 @@
+package conveyor
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+// future implements the faces.IFuture interface.
+type future struct {
+	id   int64
+	once sync.Once
+	done chan struct{}
+
+	data interface{}
+	err  error
+
+	cancel func()
+}
+
+// resolve fixes the result of item, nil item means that conveyor is stopped before the item is processed.
+func (f *future) resolve(item faces.IItem) {
+	f.once.Do(func() {
+		if item == nil {
+			f.err = errors.New("conveyor is stopped before the result is received")
+		} else {
+			f.data, f.err = item.Get(), item.GetError()
+		}
+
+		close(f.done)
+	})
+}
+
+// ID returns the id of item.
+func (f *future) ID() int64 {
+	return f.id
+}
+
+// Done is closed when the result is ready.
+func (f *future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result waits for the result and returns the data and error of item.
+func (f *future) Result() (interface{}, error) {
+	<-f.done
+
+	return f.data, f.err
+}
+
+// Cancel stops the item if it's still in conveyor.
+func (f *future) Cancel() {
+	f.cancel()
+}
+
+// RunAsync creates the new item over interface, sends to conveyor and returns the future of result without waiting.
+// The result is kept by future until it's read.
+func (c *Conveyor) RunAsync(i faces.IInput) (faces.IFuture, error) {
+	if err := c.beginSubmit(); err != nil {
+		return nil, err
+	}
+	defer c.endSubmit()
+
+	it := c.getItemFrommInput(i)
+
+	index, err := c.take(it)
+	if err != nil {
+		return nil, err
+	}
+
+	c.numerate(it)
+
+	f := &future{
+		id:   it.GetID(),
+		done: make(chan struct{}),
+	}
+	f.cancel = func() {
+		c.Cancel(f.id, nil)
+	}
+
+	// the latest system handler delivers the result to future
+	c.data.results.AddFunc(f.id, f.resolve)
+
+	c.send(it, index)
+
+	return f, nil
+}
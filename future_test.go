@@ -0,0 +1,67 @@
+package conveyor_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+func (s *testSuite) TestRunAsync(c *C) {
+	cv := conveyor.New(20, faces.ChanStdGo, "async")
+	c.Assert(cv.AddHandler("first", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("fail", 1, 2, newFailHandler), IsNil)
+
+	_, err := cv.RunAsync(input.New().Data(&pathMessage{}))
+	c.Assert(err, ErrorMatches, "conveyor is not started")
+
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// single goroutine keeps all items in flight
+	futures := make([]faces.IFuture, 0)
+	for i := 1; i <= 50; i++ {
+		f, err := cv.RunAsync(input.New().Data(&pathMessage{id: i}))
+		c.Assert(err, IsNil)
+		futures = append(futures, f)
+	}
+
+	for i, f := range futures {
+		<-f.Done()
+
+		res, err := f.Result()
+		c.Assert(res.(*pathMessage).id, Equals, i+1)
+		if (i+1)%3 == 0 {
+			c.Assert(err, ErrorMatches, "inner error")
+		} else {
+			c.Assert(err, IsNil)
+		}
+	}
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestRunAsyncCancel(c *C) {
+	ids := make(chan int64, 1)
+	newLongHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &longHandler{ids: ids}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "async")
+	c.Assert(cv.AddHandler("long", 1, 1, newLongHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	f, err := cv.RunAsync(input.New().Data(&pathMessage{}))
+	c.Assert(err, IsNil)
+	c.Assert(<-ids, Equals, f.ID())
+
+	f.Cancel()
+
+	_, err = f.Result()
+	c.Assert(err, ErrorMatches, fmt.Sprintf("item %d is canceled", f.ID()))
+
+	cv.WaitAndStop()
+}
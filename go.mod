@@ -1,6 +1,6 @@
 module github.com/iostrovok/conveyor
 
-go 1.17
+go 1.18
 
 require (
 	github.com/golang/mock v1.6.0
@@ -0,0 +1,231 @@
+package conveyor
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/queues"
+)
+
+// Graph is a builder of directed acyclic topology supported the faces.IGraph interface.
+type Graph struct {
+	nodes []faces.GraphNode
+	edges []faces.GraphEdge
+}
+
+// NewGraph is a constructor.
+func NewGraph() faces.IGraph {
+	return &Graph{
+		nodes: make([]faces.GraphNode, 0),
+		edges: make([]faces.GraphEdge, 0),
+	}
+}
+
+// Nodes is a simple getter.
+func (g *Graph) Nodes() []faces.GraphNode {
+	return g.nodes
+}
+
+// Edges is a simple getter.
+func (g *Graph) Edges() []faces.GraphEdge {
+	return g.edges
+}
+
+func (g *Graph) findNode(name faces.Name) bool {
+	for _, n := range g.nodes {
+		if n.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddNode adds the handler to graph. Parameter name should be unique.
+func (g *Graph) AddNode(name faces.Name, minCount, maxCount int, handler faces.GiveBirth) error {
+	if name == "" {
+		return errors.New("node name can not be empty")
+	}
+
+	if handler == nil {
+		return errors.New("node '" + string(name) + "' has no handler")
+	}
+
+	if g.findNode(name) {
+		return errors.New("not uniq node name '" + string(name) + "'")
+	}
+
+	g.nodes = append(g.nodes, faces.GraphNode{Name: name, MinCount: minCount, MaxCount: maxCount, Handler: handler})
+
+	return nil
+}
+
+// AddEdge adds the way between two nodes. Nodes may be added later.
+// If predicate is nil the edge is default for "from" node. Node may have single default edge only.
+// Edges with predicate are checked in order of adding, the first matched one is used.
+func (g *Graph) AddEdge(from, to faces.Name, predicate faces.Predicate) error {
+	if from == to {
+		return errors.New("node '" + string(from) + "' can not be linked to itself")
+	}
+
+	for _, e := range g.edges {
+		if e.From != from {
+			continue
+		}
+
+		if e.To == to {
+			return errors.New("edge '" + string(from) + "' -> '" + string(to) + "' already exists")
+		}
+
+		if e.Predicate == nil && predicate == nil {
+			return errors.New("node '" + string(from) + "' already has default edge")
+		}
+	}
+
+	g.edges = append(g.edges, faces.GraphEdge{From: from, To: to, Predicate: predicate})
+
+	return nil
+}
+
+// Validate checks the graph for cycles and dangling nodes.
+func (g *Graph) Validate() error {
+	if len(g.nodes) == 0 {
+		return errors.New("graph has no nodes")
+	}
+
+	parents := map[faces.Name]int{}
+	children := map[faces.Name][]faces.Name{}
+	for _, e := range g.edges {
+		if !g.findNode(e.From) {
+			return errors.New("edge from unknown node '" + string(e.From) + "'")
+		}
+
+		if !g.findNode(e.To) {
+			return errors.New("edge to unknown node '" + string(e.To) + "'")
+		}
+
+		parents[e.To]++
+		children[e.From] = append(children[e.From], e.To)
+	}
+
+	roots := make([]faces.Name, 0)
+	for _, n := range g.nodes {
+		if parents[n.Name] == 0 {
+			roots = append(roots, n.Name)
+		}
+	}
+
+	if len(roots) != 1 {
+		return errors.Errorf("graph should have single entry node, found %d", len(roots))
+	}
+
+	// Kahn's algorithm: each node is visited after all its parents
+	visited := 0
+	queue := roots
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		visited++
+
+		for _, child := range children[name] {
+			parents[child]--
+			if parents[child] == 0 {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	if visited != len(g.nodes) {
+		// the single entry node is found, so all the rest nodes are reachable from cycles only
+		return errors.New("graph has cycle")
+	}
+
+	return nil
+}
+
+// AddGraph builds the worker managers from graph. It replaces the AddHandler and AddBranch.
+// Items come to the entry node of graph and go to the final handlers from the nodes without default edge.
+func (c *Conveyor) AddGraph(graph faces.IGraph) error {
+	if err := graph.Validate(); err != nil {
+		return err
+	}
+
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	if c.data.firstWorkerManager != nil {
+		return errors.New("graph can not be added to conveyor with handlers")
+	}
+
+	// the conveyor is not changed until the whole graph is checked
+	if err := c.checkGraph(graph); err != nil {
+		return err
+	}
+
+	managers := map[faces.Name]faces.IManager{}
+	ins := map[faces.Name]*queues.Joint{}
+	for _, n := range graph.Nodes() {
+		c.data.uniqNames = append(c.data.uniqNames, n.Name)
+		c.data.managerCounter++
+
+		managers[n.Name] = c.newWorkerManager(n.Name, n.MinCount, n.MaxCount, n.Handler)
+		c.data.graphManagers = append(c.data.graphManagers, managers[n.Name])
+	}
+
+	hasDefault := map[faces.Name]bool{}
+	for _, e := range graph.Edges() {
+		from, to := managers[e.From], managers[e.To]
+
+		// each parent is a producer of the child's input channel
+		in, find := ins[e.To]
+		if !find {
+			in = queues.NewJoint(queues.New(c.data.workBench, c.data.chanType))
+			ins[e.To] = in
+			to.SetChanIn(in)
+		}
+
+		if e.Predicate == nil {
+			hasDefault[e.From] = true
+			from.SetNextManager(to).SetChanOut(in.AddProducer())
+			to.SetPrevManager(from)
+		} else {
+			from.AddRoute(faces.Route{Name: e.To, Ch: in.AddProducer(), Predicate: e.Predicate})
+		}
+	}
+
+	for _, n := range graph.Nodes() {
+		if _, find := ins[n.Name]; !find {
+			c.data.firstWorkerManager = managers[n.Name]
+		}
+
+		if !hasDefault[n.Name] {
+			c.data.terminalManagers = append(c.data.terminalManagers, managers[n.Name])
+		}
+	}
+
+	return nil
+}
+
+// checkGraph checks the names of graph nodes are uniq and edges link the nodes of graph.
+func (c *Conveyor) checkGraph(graph faces.IGraph) error {
+	names := map[faces.Name]bool{}
+	for _, n := range graph.Nodes() {
+		if names[n.Name] || c.hasName(n.Name) {
+			return errors.WithStack(errors.New("not uniq handler name '" + string(n.Name) + "'"))
+		}
+
+		names[n.Name] = true
+	}
+
+	for _, e := range graph.Edges() {
+		if !names[e.From] {
+			return errors.New("edge from unknown node '" + string(e.From) + "'")
+		}
+
+		if !names[e.To] {
+			return errors.New("edge to unknown node '" + string(e.To) + "'")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package conveyor_test
+
+import (
+	"context"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+func (s *testSuite) TestGraph(c *C) {
+	g := conveyor.NewGraph()
+	for _, name := range []faces.Name{"a", "b", "c", "d", "e"} {
+		c.Assert(g.AddNode(name, 1, 2, newPathHandler), IsNil)
+	}
+
+	/*
+		a -> b (even) -> d
+		a -> c -------> d -> e (even)
+	*/
+	c.Assert(g.AddEdge("a", "b", isEven), IsNil)
+	c.Assert(g.AddEdge("a", "c", nil), IsNil)
+	c.Assert(g.AddEdge("b", "d", nil), IsNil)
+	c.Assert(g.AddEdge("c", "d", nil), IsNil)
+	c.Assert(g.AddEdge("d", "e", isEven), IsNil)
+
+	cv := conveyor.New(10, faces.ChanStdGo, "graph")
+	c.Assert(cv.AddGraph(g), IsNil)
+	c.Assert(cv.AddHandler("f", 1, 2, newPathHandler), NotNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	for i := 0; i < 10; i++ {
+		res, err := cv.RunRes(input.New().Data(&pathMessage{id: i}))
+		c.Assert(err, IsNil)
+
+		if i%2 == 0 {
+			c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"a", "b", "d", "e"})
+		} else {
+			c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"a", "c", "d"})
+		}
+	}
+
+	cv.WaitAndStop()
+	c.Assert(len(cv.Statistic().ManagerData), Equals, 5)
+}
+
+func (s *testSuite) TestGraphValidate(c *C) {
+	g := conveyor.NewGraph()
+	c.Assert(g.Validate(), ErrorMatches, "graph has no nodes")
+
+	c.Assert(g.AddNode("a", 1, 2, newPathHandler), IsNil)
+	c.Assert(g.AddNode("a", 1, 2, newPathHandler), NotNil)
+	c.Assert(g.AddNode("b", 1, 2, newPathHandler), IsNil)
+	c.Assert(g.AddNode("c", 1, 2, newPathHandler), IsNil)
+	c.Assert(g.Validate(), ErrorMatches, "graph should have single entry node, found 3")
+
+	c.Assert(g.AddEdge("a", "a", nil), NotNil)
+	c.Assert(g.AddEdge("a", "b", nil), IsNil)
+	c.Assert(g.AddEdge("a", "b", isEven), NotNil)
+	c.Assert(g.AddEdge("a", "c", nil), NotNil)
+	c.Assert(g.AddEdge("a", "c", isEven), IsNil)
+	c.Assert(g.Validate(), IsNil)
+
+	c.Assert(g.AddEdge("b", "x", nil), IsNil)
+	c.Assert(g.Validate(), ErrorMatches, "edge to unknown node 'x'")
+
+	g = conveyor.NewGraph()
+	for _, name := range []faces.Name{"a", "b", "c"} {
+		c.Assert(g.AddNode(name, 1, 2, newPathHandler), IsNil)
+	}
+
+	c.Assert(g.AddEdge("a", "b", nil), IsNil)
+	c.Assert(g.AddEdge("b", "c", nil), IsNil)
+	c.Assert(g.AddEdge("c", "b", isEven), IsNil)
+	c.Assert(g.Validate(), ErrorMatches, "graph has cycle")
+
+	cv := conveyor.New(10, faces.ChanStdGo, "graph")
+	c.Assert(cv.AddGraph(g), NotNil)
+}
+
+func (s *testSuite) TestGraphNotUniq(c *C) {
+	g := conveyor.NewGraph()
+	for _, name := range []faces.Name{"a", "b", "c"} {
+		c.Assert(g.AddNode(name, 1, 2, newPathHandler), IsNil)
+	}
+
+	c.Assert(g.AddEdge("a", "b", nil), IsNil)
+	c.Assert(g.AddEdge("b", "c", nil), IsNil)
+
+	cv := conveyor.New(10, faces.ChanStdGo, "graph")
+	c.Assert(cv.AddErrorHandler("c", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.AddGraph(g), ErrorMatches, "not uniq handler name 'c'")
+
+	// failed graph leaves nothing in conveyor
+	g = conveyor.NewGraph()
+	for _, name := range []faces.Name{"a", "b"} {
+		c.Assert(g.AddNode(name, 1, 2, newPathHandler), IsNil)
+	}
+
+	c.Assert(g.AddEdge("a", "b", nil), IsNil)
+	c.Assert(cv.AddGraph(g), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	res, err := cv.RunRes(input.New().Data(&pathMessage{id: 1}))
+	c.Assert(err, IsNil)
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"a", "b"})
+
+	cv.WaitAndStop()
+	c.Assert(len(cv.Statistic().ManagerData), Equals, 2)
+}
@@ -16,6 +16,7 @@ type Input struct {
 	tracer   faces.ITrace
 	priority *int
 	name     faces.Name
+	key      string
 }
 
 // New is a constructor.
@@ -58,6 +59,13 @@ func (i *Input) SkipToName(name faces.Name) faces.IInput {
 	return i
 }
 
+// Key is a simple setter. Items with the same key are processed one by one by managers with key order.
+func (i *Input) Key(key string) faces.IInput {
+	i.key = key
+
+	return i
+}
+
 // Values is a simple getter.
 func (i *Input) Values() (context.Context, faces.ITrace, interface{}, *int, faces.Name) {
 	return i.ctx, i.tracer, i.data, i.priority, i.name
@@ -67,3 +75,8 @@ func (i *Input) Values() (context.Context, faces.ITrace, interface{}, *int, face
 func (i *Input) Ctx() context.Context {
 	return i.ctx
 }
+
+// GetKey is a simple getter.
+func (i *Input) GetKey() string {
+	return i.key
+}
@@ -13,9 +13,9 @@ import (
 
 const defaultResultChLen = 2
 
+// oneResult delivers the processed item, nil item means that conveyor is stopped.
 type oneResult struct {
-	ch  chan faces.IItem
-	ctx context.Context
+	deliver func(item faces.IItem)
 }
 
 type myMap struct {
@@ -53,42 +53,134 @@ func (m *myMap) Range(f func(key int64, res *oneResult) bool) {
 	}
 }
 
-// global vars.
-var allResults *myMap
+// Results is a storage of items which are waiting for result. Each conveyor has own storage.
+type Results struct {
+	sync.RWMutex
+
+	allResults  *myMap
+	deadLetters faces.IDeadLetterStore
+}
 
 // SystemFinalHandler implements the final manager with support the online processing.
 type SystemFinalHandler struct {
 	faces.EmptyHandler // defines unused methods
+
+	results *Results
+
+	// the handler delivers results itself, it's used by the package Init only
+	deliver bool
+}
+
+// defaultResults is the storage of the package functions Init and AddID.
+var defaultResults = New()
+
+// Init returns the SystemFinalHandler init method which uses the package storage of results.
+// The handler delivers the results to AddID.
+//
+// Deprecated: each conveyor has own storage, use Results.Init.
+func Init() faces.GiveBirth {
+	return func(name faces.Name) (faces.IHandler, error) {
+		return &SystemFinalHandler{results: defaultResults, deliver: true}, nil
+	}
 }
 
-func init() {
-	allResults = &myMap{
+// AddID adds new item to waiting of result in the package storage, see Init.
+// The result channel is buffered, so the context is not used more.
+//
+// Deprecated: each conveyor has own storage, use Results.AddID.
+func AddID(_ context.Context, id int64) chan faces.IItem {
+	return defaultResults.AddID(id)
+}
+
+// New is a constructor.
+func New() *Results {
+	return &Results{
+		allResults: newMap(),
+	}
+}
+
+func newMap() *myMap {
+	return &myMap{
 		data: map[int64]*oneResult{},
 	}
 }
 
+func (r *Results) results() *myMap {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.allResults
+}
+
 // Init returns the SystemFinalHandler init method.
-func Init() faces.GiveBirth {
+// The results are delivered by Deliver when item leaves the conveyor.
+func (r *Results) Init() faces.GiveBirth {
 	return func(name faces.Name) (faces.IHandler, error) {
-		return &SystemFinalHandler{}, nil
+		return &SystemFinalHandler{results: r}, nil
 	}
 }
 
-// AddID adds new item to waiting of result.
-func AddID(ctx context.Context, id int64) chan faces.IItem {
-	if ctx == nil {
-		ctx = context.Background()
+// AddID adds new item to waiting of result. The channel gets the item and is closed.
+// It's buffered, the result is not lost if nobody reads it.
+func (r *Results) AddID(id int64) chan faces.IItem {
+	ch := make(chan faces.IItem, defaultResultChLen)
+
+	r.AddFunc(id, func(item faces.IItem) {
+		if item != nil {
+			ch <- item
+		}
+
+		close(ch)
+	})
+
+	return ch
+}
+
+// AddFunc adds new item to waiting of result. Deliver is called once with processed item
+// or with nil if conveyor is stopped before.
+func (r *Results) AddFunc(id int64, deliver func(item faces.IItem)) {
+	r.results().Store(id, &oneResult{deliver: deliver})
+}
+
+// Deliver gives the processed item to its waiting if it's added by AddID or AddFunc.
+// It's called when item leaves the conveyor, so all handlers have done with the item.
+func (r *Results) Deliver(item faces.IItem) {
+	if value, loaded := r.results().LoadAndDelete(item.GetID()); loaded {
+		value.deliver(item)
 	}
+}
 
-	ch := make(chan faces.IItem, defaultResultChLen)
-	res := &oneResult{
-		ch:  ch,
-		ctx: ctx,
+// SetDeadLetterStore sets up the store of items which have left the conveyor with an error.
+func (r *Results) SetDeadLetterStore(store faces.IDeadLetterStore) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.deadLetters = store
+}
+
+// deadLetter saves the failed item to dead-letter store if it's set up.
+// Canceled items are not failed ones, they are not saved.
+func (r *Results) deadLetter(item faces.IItem) {
+	r.RLock()
+	store := r.deadLetters
+	r.RUnlock()
+
+	err := item.GetError()
+	if store == nil || err == nil || item.GetCancelReason() != nil {
+		return
 	}
 
-	allResults.Store(id, res)
+	letter := &faces.DeadLetter{
+		ID:    item.GetID(),
+		Data:  item.Get(),
+		Error: err.Error(),
+		Stage: item.GetHandlerError(),
+		Time:  time.Now(),
+	}
 
-	return ch
+	if err := store.Save(letter); err != nil {
+		item.LogTracef("dead-letter store error: %s", err.Error())
+	}
 }
 
 // Start is an interface method.
@@ -99,36 +191,24 @@ func (m *SystemFinalHandler) Start(_ context.Context) error {
 // Stop is an interface method.
 func (m *SystemFinalHandler) Stop(_ context.Context) {
 	closeFunc := func(key int64, res *oneResult) bool {
-		close(res.ch)
-		res.ch = nil
+		res.deliver(nil)
 		return true
 	}
 
-	allResults.Range(closeFunc)
-	allResults = &myMap{
-		data: map[int64]*oneResult{},
-	}
-}
-
-func runOne(res *oneResult, item faces.IItem) {
-	select {
-	case res.ch <- item: /* nothing */
-	case <-res.ctx.Done(): /* nothing */
-	case <-time.After(time.Minute): /* nothing */
-	}
+	m.results.Lock()
+	defer m.results.Unlock()
 
-	// Out of sight, out of mind
-	close(res.ch)
-	res.ch = nil
+	m.results.allResults.Range(closeFunc)
+	m.results.allResults = newMap()
 }
 
 // Run is an interface method.
 // Check the uniq id of item and returns the result if it's necessary.
 func (m *SystemFinalHandler) Run(item faces.IItem) error {
+	m.results.deadLetter(item)
 
-	id := item.GetID()
-	if value, loaded := allResults.LoadAndDelete(id); loaded {
-		go runOne(value, item)
+	if m.deliver {
+		m.results.Deliver(item)
 	}
 
 	return nil
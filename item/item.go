@@ -31,13 +31,35 @@ type Data struct {
 	tracer faces.ITrace
 	err    error
 
+	// errors of error handlers, see AddHandlingError
+	handlingErrs []error
+
 	startTime      time.Time
 	localStartTime time.Time
 
 	lastHandler faces.Name
 	skipToName  faces.Name
 	skipNames   []faces.Name
+	routeTo     faces.Name
+	hops        int
+	resume      faces.Name
+	resumes     int
+	split       []interface{}
+	parent      faces.IItem
+	children    []faces.IItem
+	hold        bool
+	key         string
+	attempts    int
 	stopped     bool
+	reason      error
+
+	// status of item, see Status
+	stage      faces.Name
+	state      faces.ItemState
+	stages     []faces.StageTime
+	pushedAt   time.Time
+	receivedAt time.Time
+	processAt  time.Time
 
 	handlerNameWithError faces.Name
 	priority             int
@@ -176,6 +198,25 @@ func (i *Item) GetError() error {
 	return i.data.err
 }
 
+// AddHandlingError keeps the error returned by error handler separately from the error of item.
+func (i *Item) AddHandlingError(err error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.handlingErrs = append(i.data.handlingErrs, err)
+	if i.data.tracer != nil {
+		i.data.tracer.LazyPrintf("%s", err.Error())
+	}
+}
+
+// GetHandlingErrors is a interface function. It's a simple getter.
+func (i *Item) GetHandlingErrors() []error {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.handlingErrs
+}
+
 // CleanError just removes error from item.
 func (i *Item) CleanError() {
 	i.Lock()
@@ -202,6 +243,61 @@ func (i *Item) LogTracef(format string, a ...interface{}) {
 	}
 }
 
+// Snapshot returns the function which restores the current state of item.
+// It allows to pass the item through the other conveyor which sets up own ID, status, errors and so on.
+func (i *Item) Snapshot() func() {
+	i.RLock()
+	saved := *i.data
+	i.RUnlock()
+
+	return func() {
+		i.Lock()
+		defer i.Unlock()
+
+		*i.data = saved
+	}
+}
+
+// Detach returns the copy of item and the function which takes the state of copy back to item.
+// The handler gets the copy if its call may be given up (see IConveyor.SetTimeout), the item goes further
+// without waiting for the handler and the hung call changes the copy only.
+func (i *Item) Detach() (faces.IItem, func()) {
+	i.RLock()
+	saved := *i.data
+	i.RUnlock()
+
+	// slices are not shared, the item and the copy may be changed at the same time
+	saved.handlingErrs = append([]error{}, saved.handlingErrs...)
+	saved.skipNames = append([]faces.Name{}, saved.skipNames...)
+	saved.split = append([]interface{}{}, saved.split...)
+	saved.children = append([]faces.IItem{}, saved.children...)
+	saved.stages = append([]faces.StageTime{}, saved.stages...)
+
+	cp := &Item{data: &saved}
+
+	return cp, func() {
+		cp.RLock()
+		state := *cp.data
+		cp.RUnlock()
+
+		i.Lock()
+		defer i.Unlock()
+
+		// the item may be canceled while the copy is processed
+		state.reason = i.data.reason
+		state.stopped = state.stopped || i.data.stopped
+		*i.data = state
+	}
+}
+
+// GetTrace is a interface function. It's a simple getter.
+func (i *Item) GetTrace() faces.ITrace {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.tracer
+}
+
 // Finish writes tracer for item and flush the tracer.
 func (i *Item) Finish() {
 	i.Lock()
@@ -234,6 +330,22 @@ func (i *Item) Cancel() {
 	}
 }
 
+// SetCancelReason is a simple setter. The reason is an error of canceled item instead of the context error.
+func (i *Item) SetCancelReason(reason error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.reason = reason
+}
+
+// GetCancelReason returns the reason which was set up with SetCancelReason.
+func (i *Item) GetCancelReason() error {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.reason
+}
+
 // LogTraceFinishTimef adds the message and during of period from
 // last call of item.LogTraceFinishTimef or item.Start to tracer.
 func (i *Item) LogTraceFinishTimef(format string, a ...interface{}) {
@@ -310,20 +422,115 @@ func (i *Item) SetLastHandler(handlerName faces.Name) {
 	i.data.lastHandler = handlerName
 }
 
-// PushedToChannel does nothing. It should be redefined.
-func (i *Item) PushedToChannel(_ faces.Name) {
+// PushedToChannel fixes that item is queued to the handler.
+func (i *Item) PushedToChannel(label faces.Name) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.stage = label
+	i.data.state = faces.ItemQueued
+	i.data.pushedAt = time.Now()
 }
 
-// ReceivedFromChannel does nothing. It should be redefined.
+// ReceivedFromChannel fixes the end of waiting in channel.
 func (i *Item) ReceivedFromChannel() {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.receivedAt = time.Now()
 }
 
-// BeforeProcess does nothing. It should be redefined.
-func (i *Item) BeforeProcess(_ faces.Name) {
+// BeforeProcess fixes that item is being processed by the handler.
+// The time in channel is counted for the handler which has received the item.
+func (i *Item) BeforeProcess(label faces.Name) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.processAt = time.Now()
+
+	stage := i.stageTime(label)
+	if !i.data.pushedAt.IsZero() && i.data.receivedAt.After(i.data.pushedAt) {
+		stage.Queued += i.data.receivedAt.Sub(i.data.pushedAt)
+	}
+
+	i.data.stage = label
+	i.data.state = faces.ItemProcessing
+	i.data.pushedAt = time.Time{}
+}
+
+// AfterProcess fixes the end of processing by the handler.
+func (i *Item) AfterProcess(label faces.Name, _ error) {
+	i.Lock()
+	defer i.Unlock()
+
+	stage := i.stageTime(label)
+	if !i.data.processAt.IsZero() {
+		stage.Processed += time.Since(i.data.processAt)
+	}
+
+	i.data.stage = label
+	i.data.state = faces.ItemWaiting
+	i.data.processAt = time.Time{}
+}
+
+// stageTime returns the time of stage, item may visit the stage several times.
+func (i *Item) stageTime(label faces.Name) *faces.StageTime {
+	for k := range i.data.stages {
+		if i.data.stages[k].Name == label {
+			return &i.data.stages[k]
+		}
+	}
+
+	i.data.stages = append(i.data.stages, faces.StageTime{Name: label})
+
+	return &i.data.stages[len(i.data.stages)-1]
+}
+
+// Status returns the snapshot of item condition.
+func (i *Item) Status() *faces.ItemStatus {
+	i.RLock()
+	defer i.RUnlock()
+
+	out := &faces.ItemStatus{
+		ID:         i.data.id,
+		Stage:      i.data.stage,
+		State:      i.data.state,
+		Stages:     make([]faces.StageTime, len(i.data.stages)),
+		Priority:   i.data.priority,
+		Error:      i.data.err,
+		SkipToName: i.data.skipToName,
+		SkipNames:  append([]faces.Name{}, i.data.skipNames...),
+		Stopped:    i.data.stopped,
+	}
+
+	copy(out.Stages, i.data.stages)
+
+	// the current stage is not finished yet
+	switch i.data.state {
+	case faces.ItemQueued:
+		if !i.data.pushedAt.IsZero() {
+			out.Stages = addTime(out.Stages, i.data.stage, time.Since(i.data.pushedAt), 0)
+		}
+	case faces.ItemProcessing:
+		if !i.data.processAt.IsZero() {
+			out.Stages = addTime(out.Stages, i.data.stage, 0, time.Since(i.data.processAt))
+		}
+	}
+
+	return out
 }
 
-// AfterProcess does nothing. It should be redefined.
-func (i *Item) AfterProcess(_ faces.Name, _ error) {
+func addTime(stages []faces.StageTime, label faces.Name, queued, processed time.Duration) []faces.StageTime {
+	for k := range stages {
+		if stages[k].Name == label {
+			stages[k].Queued += queued
+			stages[k].Processed += processed
+
+			return stages
+		}
+	}
+
+	return append(stages, faces.StageTime{Name: label, Queued: queued, Processed: processed})
 }
 
 // SetSkipToName sets the handler name. Conveyor skips all handlers until that.
@@ -360,6 +567,184 @@ func (i *Item) GetSkipNames() []faces.Name {
 	return i.data.skipNames
 }
 
+// RouteTo sets the handler name. Conveyor sends item to that handler after the current one.
+// The handler may be earlier or later in the chain.
+func (i *Item) RouteTo(name faces.Name) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.routeTo = name
+}
+
+// GetRouteTo returns handler name which was set up with RouteTo and is not yet processed.
+func (i *Item) GetRouteTo() faces.Name {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.routeTo
+}
+
+// Hop cleans the handler name which was set up with RouteTo and increases the number of routes.
+// It returns the number of routes which were made for item.
+func (i *Item) Hop() int {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.routeTo = faces.EmptySkipName
+	i.data.hops++
+
+	return i.data.hops
+}
+
+// GetHops returns the number of routes which were made for item.
+func (i *Item) GetHops() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.hops
+}
+
+// Resume sets the handler name. Error handler uses it to send the repaired item back to the worker handlers,
+// the error of item is cleaned. It makes sense in error handlers only.
+func (i *Item) Resume(name faces.Name) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.resume = name
+}
+
+// GetResume returns handler name which was set up with Resume and is not yet processed.
+func (i *Item) GetResume() faces.Name {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.resume
+}
+
+// Resumed cleans the handler name which was set up with Resume and increases the number of resumes.
+// It returns the number of resumes which were made for item.
+func (i *Item) Resumed() int {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.resume = faces.EmptySkipName
+	i.data.resumes++
+
+	return i.data.resumes
+}
+
+// GetResumes returns the number of resumes which were made for item.
+func (i *Item) GetResumes() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.resumes
+}
+
+// Split requests the child items with data. Conveyor creates them after the current handler.
+// The item waits for all children and goes to the final handlers with the list of their results.
+func (i *Item) Split(data ...interface{}) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.split = append(i.data.split, data...)
+}
+
+// TakeSplit returns and cleans the data which was set up with Split.
+func (i *Item) TakeSplit() []interface{} {
+	i.Lock()
+	defer i.Unlock()
+
+	out := i.data.split
+	i.data.split = nil
+
+	return out
+}
+
+// SetParent is a simple setter.
+func (i *Item) SetParent(parent faces.IItem) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.parent = parent
+}
+
+// GetParent returns the item which this one was split from or nil.
+func (i *Item) GetParent() faces.IItem {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.parent
+}
+
+// AddChild adds the item which was split from this one.
+func (i *Item) AddChild(child faces.IItem) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.children = append(i.data.children, child)
+}
+
+// GetChildren returns the items which were split from this one.
+func (i *Item) GetChildren() []faces.IItem {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.children
+}
+
+// SetAttempts is a simple setter. Worker sets up the number of the current attempt before each handler call.
+func (i *Item) SetAttempts(attempts int) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.attempts = attempts
+}
+
+// GetAttempts returns the number of the current attempt of processing at the current stage.
+func (i *Item) GetAttempts() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.attempts
+}
+
+// SetKey is a simple setter. The key is used by managers with key order.
+func (i *Item) SetKey(key string) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.key = key
+}
+
+// GetKey is a simple getter.
+func (i *Item) GetKey() string {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.data.key
+}
+
+// Hold keeps the item in work bench after the current handler.
+// The handler is responsible for sending item further by itself.
+func (i *Item) Hold() {
+	i.Lock()
+	defer i.Unlock()
+
+	i.data.hold = true
+}
+
+// TakeHold returns and cleans the flag which was set up with Hold.
+func (i *Item) TakeHold() bool {
+	i.Lock()
+	defer i.Unlock()
+
+	out := i.data.hold
+	i.data.hold = false
+
+	return out
+}
+
 // NeedToSkip checks should be handler skipped or not.
 func (i *Item) NeedToSkip(worker faces.IWorker) (bool, error) {
 	name, typ, isLast := worker.GetBorderCond()
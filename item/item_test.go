@@ -11,6 +11,7 @@ import (
 	"github.com/iostrovok/conveyor/faces"
 	"github.com/iostrovok/conveyor/faces/mmock"
 	"github.com/iostrovok/conveyor/item"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -67,3 +68,93 @@ func checkResult(c *C, item faces.IItem, w faces.IWorker, needSkip bool) {
 	c.Assert(err, IsNil)
 	c.Assert(skip, Equals, needSkip)
 }
+
+func (s *testSuite) TestRouteTo(c *C) {
+	it := item.New(context.Background(), nil)
+	c.Assert(it.GetRouteTo(), Equals, faces.EmptySkipName)
+
+	it.RouteTo(NameOne)
+	c.Assert(it.GetRouteTo(), Equals, NameOne)
+	c.Assert(it.Hop(), Equals, 1)
+	c.Assert(it.GetRouteTo(), Equals, faces.EmptySkipName)
+	c.Assert(it.GetHops(), Equals, 1)
+}
+
+func (s *testSuite) TestResume(c *C) {
+	it := item.New(context.Background(), nil)
+	c.Assert(it.GetResume(), Equals, faces.EmptySkipName)
+
+	it.Resume(NameOne)
+	c.Assert(it.GetResume(), Equals, NameOne)
+	c.Assert(it.Resumed(), Equals, 1)
+	c.Assert(it.GetResume(), Equals, faces.EmptySkipName)
+	c.Assert(it.GetResumes(), Equals, 1)
+}
+
+func (s *testSuite) TestStatus(c *C) {
+	it := item.New(context.Background(), nil)
+	it.SetID(10)
+	it.SetPriority(3)
+
+	it.PushedToChannel(NameOne)
+	status := it.Status()
+	c.Assert(status.ID, Equals, int64(10))
+	c.Assert(status.Priority, Equals, 3)
+	c.Assert(status.Stage, Equals, NameOne)
+	c.Assert(status.State, Equals, faces.ItemQueued)
+
+	time.Sleep(time.Millisecond)
+	it.ReceivedFromChannel()
+	it.BeforeProcess(NameOne)
+	c.Assert(it.Status().State, Equals, faces.ItemProcessing)
+
+	it.AfterProcess(NameOne, nil)
+	status = it.Status()
+	c.Assert(status.State, Equals, faces.ItemWaiting)
+	c.Assert(len(status.Stages), Equals, 1)
+	c.Assert(status.Stages[0].Name, Equals, NameOne)
+	c.Assert(status.Stages[0].Queued >= time.Millisecond, Equals, true)
+}
+
+func (s *testSuite) TestSnapshot(c *C) {
+	it := item.New(context.Background(), nil)
+	it.SetID(10)
+	it.SetPriority(5)
+
+	restore := it.Snapshot()
+
+	it.SetID(20)
+	it.SetPriority(1)
+	it.AddError(errors.New("inner error"))
+
+	restore()
+	c.Assert(it.GetID(), Equals, int64(10))
+	c.Assert(it.GetPriority(), Equals, 5)
+	c.Assert(it.GetError(), IsNil)
+}
+
+func (s *testSuite) TestDetach(c *C) {
+	it := item.New(context.Background(), nil)
+	it.Set(1)
+
+	cp, apply := it.Detach()
+	cp.Set(2)
+	cp.RouteTo("next")
+	c.Assert(it.Get(), Equals, 1)
+	c.Assert(it.GetRouteTo(), Equals, faces.EmptySkipName)
+
+	// the cancel reason of item is kept
+	reason := errors.New("canceled")
+	it.SetCancelReason(reason)
+	it.Stopped()
+
+	apply()
+	c.Assert(it.Get(), Equals, 2)
+	c.Assert(it.GetRouteTo(), Equals, faces.Name("next"))
+	c.Assert(it.GetCancelReason(), Equals, reason)
+	c.Assert(it.IsStopped(), Equals, true)
+
+	// the copy is not linked to item after apply
+	cp.Set(3)
+	c.Assert(it.Get(), Equals, 2)
+}
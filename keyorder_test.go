@@ -0,0 +1,136 @@
+package conveyor_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type ledgerHandler struct {
+	faces.EmptyHandler
+
+	sync.Mutex
+	active   map[string]bool
+	order    map[string][]int
+	conflict bool
+}
+
+func (h *ledgerHandler) Run(item faces.IItem) error {
+	key := item.GetKey()
+
+	h.Lock()
+	if h.active[key] {
+		h.conflict = true
+	}
+	h.active[key] = true
+	h.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	h.Lock()
+	h.active[key] = false
+	h.order[key] = append(h.order[key], item.Get().(int))
+	h.Unlock()
+
+	return nil
+}
+
+func (s *testSuite) TestKeyOrder(c *C) {
+	for _, chanType := range []faces.ChanType{faces.ChanStdGo, faces.ChaPriorityQueue} {
+		ledger := &ledgerHandler{active: map[string]bool{}, order: map[string][]int{}}
+		newLedger := func(_ faces.Name) (faces.IHandler, error) {
+			return ledger, nil
+		}
+
+		cv := conveyor.New(30, chanType, "key-order")
+		c.Assert(cv.AddHandler("ledger", 4, 4, newLedger), IsNil)
+		c.Assert(cv.SetKeyOrder("ledger", nil), IsNil)
+		c.Assert(cv.Start(context.Background()), IsNil)
+
+		for i := 0; i < 60; i++ {
+			cv.Run(input.New().Data(i).Key("account-" + strconv.Itoa(i%3)))
+		}
+
+		cv.WaitAndStop()
+
+		c.Assert(ledger.conflict, Equals, false)
+		c.Assert(len(ledger.order), Equals, 3)
+		for key, order := range ledger.order {
+			c.Assert(len(order), Equals, 20, Commentf("key %s", key))
+			for k := 1; k < len(order); k++ {
+				c.Assert(order[k-1] < order[k], Equals, true, Commentf("key %s: %v", key, order))
+			}
+		}
+	}
+}
+
+func (s *testSuite) TestKeyOrderErrors(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "key-order")
+	c.Assert(cv.AddHandler("first", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.SetKeyOrder("unknown", nil), NotNil)
+}
+
+type orderHandler struct {
+	faces.EmptyHandler
+
+	sync.Mutex
+	release chan struct{}
+	order   []int
+}
+
+// Run waits for release on the first item and records the order of items.
+func (h *orderHandler) Run(item faces.IItem) error {
+	h.Lock()
+	h.order = append(h.order, item.Get().(int))
+	first := len(h.order) == 1
+	h.Unlock()
+
+	if first {
+		<-h.release
+	}
+
+	return nil
+}
+
+func (s *testSuite) TestKeyOrderQueueType(c *C) {
+	handler := &orderHandler{release: make(chan struct{})}
+	newOrder := func(_ faces.Name) (faces.IHandler, error) {
+		return handler, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStack, "key-order")
+	c.Assert(cv.AddHandler("order", 1, 1, newOrder), IsNil)
+	c.Assert(cv.SetKeyOrder("order", nil), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	c.Assert(cv.Run(input.New().Data(0).Key("first")), IsNil)
+	for handler.orderLen() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// the waiting items with different keys are taken from the stack,
+	// the first two of them are already pulled out of the stack by the worker channels
+	for i := 1; i <= 5; i++ {
+		c.Assert(cv.Run(input.New().Data(i).Key(strconv.Itoa(i))), IsNil)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	close(handler.release)
+	cv.WaitAndStop()
+
+	c.Assert(handler.order, DeepEquals, []int{0, 1, 2, 5, 4, 3})
+}
+
+func (h *orderHandler) orderLen() int {
+	h.Lock()
+	defer h.Unlock()
+
+	return len(h.order)
+}
@@ -195,6 +195,57 @@ func (ChanType) EnumDescriptor() ([]byte, []int) {
 	return file_protobuf_proto_masternode_proto_rawDescGZIP(), []int{2}
 }
 
+//*
+// enum BreakerState is a state of circuit breaker of manager
+type BreakerState int32
+
+const (
+	BreakerState_BREAKER_CLOSED    BreakerState = 0 // items are processed as usual
+	BreakerState_BREAKER_OPEN      BreakerState = 1 // items are not processed by handler
+	BreakerState_BREAKER_HALF_OPEN BreakerState = 2 // single item is processed to probe the handler
+)
+
+// Enum value maps for BreakerState.
+var (
+	BreakerState_name = map[int32]string{
+		0: "BREAKER_CLOSED",
+		1: "BREAKER_OPEN",
+		2: "BREAKER_HALF_OPEN",
+	}
+	BreakerState_value = map[string]int32{
+		"BREAKER_CLOSED":    0,
+		"BREAKER_OPEN":      1,
+		"BREAKER_HALF_OPEN": 2,
+	}
+)
+
+func (x BreakerState) Enum() *BreakerState {
+	p := new(BreakerState)
+	*p = x
+	return p
+}
+
+func (x BreakerState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BreakerState) Descriptor() protoreflect.EnumDescriptor {
+	return file_protobuf_proto_masternode_proto_enumTypes[3].Descriptor()
+}
+
+func (BreakerState) Type() protoreflect.EnumType {
+	return &file_protobuf_proto_masternode_proto_enumTypes[3]
+}
+
+func (x BreakerState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BreakerState.Descriptor instead.
+func (BreakerState) EnumDescriptor() ([]byte, []int) {
+	return file_protobuf_proto_masternode_proto_rawDescGZIP(), []int{3}
+}
+
 type ManagerAction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -462,6 +513,71 @@ func (x *WorkersData) GetActive() uint32 {
 	return 0
 }
 
+//*
+// message BreakerData contents the information about circuit breaker for single manager
+type BreakerData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State     BreakerState `protobuf:"varint,1,opt,name=State,proto3,enum=nodes.BreakerState" json:"State,omitempty"`
+	ErrorRate float32      `protobuf:"fixed32,2,opt,name=ErrorRate,proto3" json:"ErrorRate,omitempty"` // rate of errors in the sliding window
+	Opened    uint64       `protobuf:"varint,3,opt,name=Opened,proto3" json:"Opened,omitempty"`        // number of times the breaker was opened
+}
+
+func (x *BreakerData) Reset() {
+	*x = BreakerData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protobuf_proto_masternode_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BreakerData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakerData) ProtoMessage() {}
+
+func (x *BreakerData) ProtoReflect() protoreflect.Message {
+	mi := &file_protobuf_proto_masternode_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakerData.ProtoReflect.Descriptor instead.
+func (*BreakerData) Descriptor() ([]byte, []int) {
+	return file_protobuf_proto_masternode_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BreakerData) GetState() BreakerState {
+	if x != nil {
+		return x.State
+	}
+	return BreakerState_BREAKER_CLOSED
+}
+
+func (x *BreakerData) GetErrorRate() float32 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *BreakerData) GetOpened() uint64 {
+	if x != nil {
+		return x.Opened
+	}
+	return 0
+}
+
 //*
 // message ManagerData contents the information about single manager
 type ManagerData struct {
@@ -476,12 +592,13 @@ type ManagerData struct {
 	Workers    *WorkersData         `protobuf:"bytes,5,opt,name=Workers,proto3" json:"Workers,omitempty"`
 	ChanBefore []*ChanData          `protobuf:"bytes,6,rep,name=ChanBefore,proto3" json:"ChanBefore,omitempty"`
 	ChanAfter  []*ChanData          `protobuf:"bytes,7,rep,name=ChanAfter,proto3" json:"ChanAfter,omitempty"`
+	Breaker    *BreakerData         `protobuf:"bytes,8,opt,name=Breaker,proto3" json:"Breaker,omitempty"` // it's empty if manager has no circuit breaker
 }
 
 func (x *ManagerData) Reset() {
 	*x = ManagerData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_proto_masternode_proto_msgTypes[4]
+		mi := &file_protobuf_proto_masternode_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -494,7 +611,7 @@ func (x *ManagerData) String() string {
 func (*ManagerData) ProtoMessage() {}
 
 func (x *ManagerData) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_proto_masternode_proto_msgTypes[4]
+	mi := &file_protobuf_proto_masternode_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -507,7 +624,7 @@ func (x *ManagerData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ManagerData.ProtoReflect.Descriptor instead.
 func (*ManagerData) Descriptor() ([]byte, []int) {
-	return file_protobuf_proto_masternode_proto_rawDescGZIP(), []int{4}
+	return file_protobuf_proto_masternode_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ManagerData) GetName() string {
@@ -559,6 +676,13 @@ func (x *ManagerData) GetChanAfter() []*ChanData {
 	return nil
 }
 
+func (x *ManagerData) GetBreaker() *BreakerData {
+	if x != nil {
+		return x.Breaker
+	}
+	return nil
+}
+
 //*
 // SlaveNodeInfoRequest is a request with slave node data to master node
 type SlaveNodeInfoRequest struct {
@@ -576,7 +700,7 @@ type SlaveNodeInfoRequest struct {
 func (x *SlaveNodeInfoRequest) Reset() {
 	*x = SlaveNodeInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protobuf_proto_masternode_proto_msgTypes[5]
+		mi := &file_protobuf_proto_masternode_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -589,7 +713,7 @@ func (x *SlaveNodeInfoRequest) String() string {
 func (*SlaveNodeInfoRequest) ProtoMessage() {}
 
 func (x *SlaveNodeInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protobuf_proto_masternode_proto_msgTypes[5]
+	mi := &file_protobuf_proto_masternode_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,7 +726,7 @@ func (x *SlaveNodeInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SlaveNodeInfoRequest.ProtoReflect.Descriptor instead.
 func (*SlaveNodeInfoRequest) Descriptor() ([]byte, []int) {
-	return file_protobuf_proto_masternode_proto_rawDescGZIP(), []int{5}
+	return file_protobuf_proto_masternode_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SlaveNodeInfoRequest) GetClusterID() string {
@@ -673,7 +797,14 @@ var file_protobuf_proto_masternode_proto_rawDesc = []byte{
 	0x4d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x4d, 0x61, 0x78, 0x12, 0x16,
 	0x0a, 0x06, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
 	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x22, 0x8b,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x22, 0x6e,
+	0x0a, 0x0b, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a,
+	0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x6e,
+	0x6f, 0x64, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x22, 0xb9,
 	0x02, 0x0a, 0x0b, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12,
 	0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61,
 	0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
@@ -690,45 +821,53 @@ var file_protobuf_proto_masternode_proto_rawDesc = []byte{
 	0x61, 0x52, 0x0a, 0x43, 0x68, 0x61, 0x6e, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x2d, 0x0a,
 	0x09, 0x43, 0x68, 0x61, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x09, 0x43, 0x68, 0x61, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x82, 0x02, 0x0a,
-	0x14, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
-	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
-	0x72, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x0b, 0x4d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
-	0x44, 0x61, 0x74, 0x61, 0x52, 0x0b, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x3e, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f,
-	0x64, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x10, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x3e, 0x0a, 0x10, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f,
-	0x64, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x10, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74,
-	0x61, 0x2a, 0x27, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x4e,
-	0x4f, 0x54, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x55, 0x50, 0x10, 0x01,
-	0x12, 0x08, 0x0a, 0x04, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x2a, 0x6e, 0x0a, 0x04, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
-	0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x57, 0x4f, 0x52,
-	0x4b, 0x45, 0x52, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x48,
-	0x41, 0x4e, 0x4e, 0x45, 0x4c, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x59, 0x50,
-	0x45, 0x5f, 0x4e, 0x4f, 0x44, 0x45, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x10, 0x05, 0x2a, 0x56, 0x0a, 0x08, 0x43, 0x68,
-	0x61, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x41, 0x4e, 0x5f, 0x55,
-	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x48, 0x41, 0x4e,
-	0x5f, 0x53, 0x54, 0x44, 0x5f, 0x47, 0x4f, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x48, 0x41,
-	0x4e, 0x5f, 0x53, 0x54, 0x41, 0x43, 0x4b, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x48, 0x41,
-	0x4e, 0x5f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45,
-	0x10, 0x03, 0x32, 0x50, 0x0a, 0x0a, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65,
-	0x12, 0x42, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x1b, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65,
-	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x13, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x42, 0x0a, 0x5a, 0x08, 0x67, 0x6f, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x73,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x52, 0x09, 0x43, 0x68, 0x61, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x07,
+	0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x07, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x22, 0x82, 0x02, 0x0a, 0x14, 0x53,
+	0x6c, 0x61, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x34, 0x0a, 0x0b, 0x4d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x0b, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x3e, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x64, 0x65,
+	0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x10, 0x46,
+	0x69, 0x6e, 0x61, 0x6c, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x3e, 0x0a, 0x10, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x64, 0x65,
+	0x73, 0x2e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x10, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x2a,
+	0x27, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x54,
+	0x48, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x55, 0x50, 0x10, 0x01, 0x12, 0x08,
+	0x0a, 0x04, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x2a, 0x6e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x57, 0x4f, 0x52, 0x4b, 0x45,
+	0x52, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e,
+	0x4e, 0x45, 0x4c, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41,
+	0x4e, 0x41, 0x47, 0x45, 0x52, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x4e, 0x4f, 0x44, 0x45, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43,
+	0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x10, 0x05, 0x2a, 0x56, 0x0a, 0x08, 0x43, 0x68, 0x61, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x41, 0x4e, 0x5f, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x48, 0x41, 0x4e, 0x5f, 0x53,
+	0x54, 0x44, 0x5f, 0x47, 0x4f, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x48, 0x41, 0x4e, 0x5f,
+	0x53, 0x54, 0x41, 0x43, 0x4b, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x48, 0x41, 0x4e, 0x5f,
+	0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x10, 0x03,
+	0x2a, 0x4b, 0x0a, 0x0c, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x12, 0x0a, 0x0e, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x45, 0x52, 0x5f, 0x43, 0x4c, 0x4f, 0x53,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x45, 0x52, 0x5f,
+	0x4f, 0x50, 0x45, 0x4e, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x45,
+	0x52, 0x5f, 0x48, 0x41, 0x4c, 0x46, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x02, 0x32, 0x50, 0x0a,
+	0x0a, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42,
+	0x0a, 0x5a, 0x08, 0x67, 0x6f, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -743,38 +882,42 @@ func file_protobuf_proto_masternode_proto_rawDescGZIP() []byte {
 	return file_protobuf_proto_masternode_proto_rawDescData
 }
 
-var file_protobuf_proto_masternode_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_protobuf_proto_masternode_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_protobuf_proto_masternode_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_protobuf_proto_masternode_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_protobuf_proto_masternode_proto_goTypes = []interface{}{
 	(Action)(0),                  // 0: nodes.Action
 	(Type)(0),                    // 1: nodes.Type
 	(ChanType)(0),                // 2: nodes.ChanType
-	(*ManagerAction)(nil),        // 3: nodes.ManagerAction
-	(*SimpleResult)(nil),         // 4: nodes.SimpleResult
-	(*ChanData)(nil),             // 5: nodes.ChanData
-	(*WorkersData)(nil),          // 6: nodes.WorkersData
-	(*ManagerData)(nil),          // 7: nodes.ManagerData
-	(*SlaveNodeInfoRequest)(nil), // 8: nodes.SlaveNodeInfoRequest
-	(*timestamp.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+	(BreakerState)(0),            // 3: nodes.BreakerState
+	(*ManagerAction)(nil),        // 4: nodes.ManagerAction
+	(*SimpleResult)(nil),         // 5: nodes.SimpleResult
+	(*ChanData)(nil),             // 6: nodes.ChanData
+	(*WorkersData)(nil),          // 7: nodes.WorkersData
+	(*BreakerData)(nil),          // 8: nodes.BreakerData
+	(*ManagerData)(nil),          // 9: nodes.ManagerData
+	(*SlaveNodeInfoRequest)(nil), // 10: nodes.SlaveNodeInfoRequest
+	(*timestamp.Timestamp)(nil),  // 11: google.protobuf.Timestamp
 }
 var file_protobuf_proto_masternode_proto_depIdxs = []int32{
 	1,  // 0: nodes.ManagerAction.Type:type_name -> nodes.Type
 	0,  // 1: nodes.ManagerAction.Action:type_name -> nodes.Action
 	2,  // 2: nodes.ChanData.Type:type_name -> nodes.ChanType
-	9,  // 3: nodes.ManagerData.Created:type_name -> google.protobuf.Timestamp
-	6,  // 4: nodes.ManagerData.Workers:type_name -> nodes.WorkersData
-	5,  // 5: nodes.ManagerData.ChanBefore:type_name -> nodes.ChanData
-	5,  // 6: nodes.ManagerData.ChanAfter:type_name -> nodes.ChanData
-	7,  // 7: nodes.SlaveNodeInfoRequest.ManagerData:type_name -> nodes.ManagerData
-	7,  // 8: nodes.SlaveNodeInfoRequest.FinalManagerData:type_name -> nodes.ManagerData
-	7,  // 9: nodes.SlaveNodeInfoRequest.ErrorManagerData:type_name -> nodes.ManagerData
-	8,  // 10: nodes.MasterNode.UpdateNodeInfo:input_type -> nodes.SlaveNodeInfoRequest
-	4,  // 11: nodes.MasterNode.UpdateNodeInfo:output_type -> nodes.SimpleResult
-	11, // [11:12] is the sub-list for method output_type
-	10, // [10:11] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	3,  // 3: nodes.BreakerData.State:type_name -> nodes.BreakerState
+	11, // 4: nodes.ManagerData.Created:type_name -> google.protobuf.Timestamp
+	7,  // 5: nodes.ManagerData.Workers:type_name -> nodes.WorkersData
+	6,  // 6: nodes.ManagerData.ChanBefore:type_name -> nodes.ChanData
+	6,  // 7: nodes.ManagerData.ChanAfter:type_name -> nodes.ChanData
+	8,  // 8: nodes.ManagerData.Breaker:type_name -> nodes.BreakerData
+	9,  // 9: nodes.SlaveNodeInfoRequest.ManagerData:type_name -> nodes.ManagerData
+	9,  // 10: nodes.SlaveNodeInfoRequest.FinalManagerData:type_name -> nodes.ManagerData
+	9,  // 11: nodes.SlaveNodeInfoRequest.ErrorManagerData:type_name -> nodes.ManagerData
+	10, // 12: nodes.MasterNode.UpdateNodeInfo:input_type -> nodes.SlaveNodeInfoRequest
+	5,  // 13: nodes.MasterNode.UpdateNodeInfo:output_type -> nodes.SimpleResult
+	13, // [13:14] is the sub-list for method output_type
+	12, // [12:13] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_protobuf_proto_masternode_proto_init() }
@@ -832,7 +975,7 @@ func file_protobuf_proto_masternode_proto_init() {
 			}
 		}
 		file_protobuf_proto_masternode_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ManagerData); i {
+			switch v := v.(*BreakerData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -844,6 +987,18 @@ func file_protobuf_proto_masternode_proto_init() {
 			}
 		}
 		file_protobuf_proto_masternode_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ManagerData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protobuf_proto_masternode_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SlaveNodeInfoRequest); i {
 			case 0:
 				return &v.state
@@ -861,8 +1016,8 @@ func file_protobuf_proto_masternode_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protobuf_proto_masternode_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   6,
+			NumEnums:      4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,42 @@
+package queues
+
+import (
+	"sync/atomic"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+// Joint wraps the queue which is filled by several managers.
+// The wrapped queue is closed when the last producer calls Close().
+type Joint struct {
+	faces.IChan
+
+	producers *int32
+}
+
+// NewJoint is a constructor. Each producer should be registered with AddProducer.
+func NewJoint(ch faces.IChan) *Joint {
+	return &Joint{
+		IChan:     ch,
+		producers: new(int32),
+	}
+}
+
+// AddProducer registers one more producer and returns the queue for it.
+func (j *Joint) AddProducer() faces.IChan {
+	atomic.AddInt32(j.producers, 1)
+
+	return j
+}
+
+// Producers returns the number of producers which have not closed the queue yet.
+func (j *Joint) Producers() int {
+	return int(atomic.LoadInt32(j.producers))
+}
+
+// Close closes the wrapped queue if it's called by the last producer.
+func (j *Joint) Close() {
+	if atomic.AddInt32(j.producers, -1) == 0 {
+		j.IChan.Close()
+	}
+}
@@ -0,0 +1,36 @@
+package conveyor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+func (s *testSuite) TestRateLimit(c *C) {
+	cv := conveyor.New(50, faces.ChanStdGo, "rate-limit")
+	c.Assert(cv.AddHandler("api", 4, 4, newPathHandler), IsNil)
+	c.Assert(cv.SetRateLimit("api", 200, 1), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	start := time.Now()
+	for i := 0; i < 21; i++ {
+		cv.Run(input.New().Data(&pathMessage{id: i}))
+	}
+
+	cv.WaitAndStop()
+
+	// 4 workers share 200 calls per second
+	c.Assert(time.Since(start) >= 100*time.Millisecond, Equals, true)
+}
+
+func (s *testSuite) TestRateLimitErrors(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "rate-limit")
+	c.Assert(cv.AddHandler("api", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.SetRateLimit("unknown", 10, 1), NotNil)
+	c.Assert(cv.SetRateLimit("api", -1, 1), NotNil)
+}
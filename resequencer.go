@@ -0,0 +1,229 @@
+package conveyor
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/queues/std"
+)
+
+const (
+	// defaultResequenceTimeout is used if the timeout of resequencer is not set up.
+	defaultResequenceTimeout = time.Second
+
+	// resequencedKey is a single key of items in the final handlers, they are processed one by one.
+	resequencedKey = "###RESEQUENCED"
+)
+
+// resequencer buffers the finished items in front of the final handlers and releases them in order of IDs.
+type resequencer struct {
+	window  int
+	timeout time.Duration
+
+	workBench faces.IWorkBench
+	in, out   faces.IChan
+
+	// the next expected id
+	next int64
+
+	// id => index of buffered item
+	buffer map[int64]int
+
+	// ids of child items which went without waiting (see IItem.Split) and items which come late, see pass
+	passedMu sync.Mutex
+	passed   map[int64]bool
+
+	// time of the last released item
+	lastRelease time.Time
+}
+
+// SetResequencer turns on the releasing of finished items to the final handlers in order of IDs.
+// Window is a max number of buffered items, the item with the smallest ID is released when the buffer is full.
+// Items which wait for missing ID longer than timeout are released too. By default timeout is 1 second.
+// Final handlers process items one by one in this case. The child items (see IItem.Split) are not ordered.
+// The split parents and items absorbed by windows are not ordered too, the next items don't wait for them.
+func (c *Conveyor) SetResequencer(window int, timeout time.Duration) faces.IConveyor {
+	if window < 1 {
+		window = 1
+	}
+
+	if timeout <= 0 {
+		timeout = defaultResequenceTimeout
+	}
+
+	c.data.resequencer = &resequencer{
+		window:    window,
+		timeout:   timeout,
+		workBench: c.data.workBench,
+		next:      1,
+		buffer:    map[int64]int{},
+		passed:    map[int64]bool{},
+	}
+
+	return c
+}
+
+// wire puts the resequencer between the out channel and the final handlers.
+func (r *resequencer) wire(in faces.IChan, final ...faces.IManager) {
+	r.in = in
+	r.out = std.New(r.workBench.Len())
+
+	keyFn := func(_ faces.IItem) string {
+		return resequencedKey
+	}
+
+	for _, mg := range final {
+		if mg != nil {
+			mg.SetKeyOrder(keyFn)
+		}
+	}
+
+	final[0].SetChanIn(r.out)
+}
+
+func (r *resequencer) run(ctx context.Context) {
+	ticker := time.NewTicker(r.tickPeriod())
+	defer ticker.Stop()
+
+	r.lastRelease = time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if len(r.buffer) > 0 && now.Sub(r.lastRelease) >= r.timeout {
+				// missing item is late, the next one goes
+				r.skipAhead()
+			}
+		case i, ok := <-r.in.ChanOut():
+			if !ok {
+				r.flush()
+
+				return
+			}
+
+			r.add(i)
+		}
+	}
+}
+
+func (r *resequencer) add(index int) {
+	it, err := r.workBench.Get(index)
+	if err != nil || it == nil {
+		r.out.Push(index)
+
+		return
+	}
+
+	id := it.GetID()
+	switch {
+	case it.GetParent() != nil:
+		// the parent waits for children, so they can't wait for it
+		r.out.Push(index)
+		r.pass(id)
+	case id < r.next:
+		// the item was skipped by timeout or window
+		r.out.Push(index)
+
+		return
+	default:
+		r.buffer[id] = index
+	}
+
+	r.release()
+
+	if len(r.buffer) > r.window {
+		r.skipAhead()
+	}
+}
+
+// pass marks the id as passed, the next items don't wait for it. The split parents and items held by windows
+// are marked when they start waiting, they come after the next items and go to the final handlers at once.
+func (r *resequencer) pass(id int64) {
+	r.passedMu.Lock()
+	defer r.passedMu.Unlock()
+
+	r.passed[id] = true
+}
+
+// isPassed checks and forgets the passed id.
+func (r *resequencer) isPassed(id int64) bool {
+	r.passedMu.Lock()
+	defer r.passedMu.Unlock()
+
+	find := r.passed[id]
+	delete(r.passed, id)
+
+	return find
+}
+
+// release sends the items to the final handlers while they come in order.
+func (r *resequencer) release() {
+	for {
+		if index, find := r.buffer[r.next]; find {
+			delete(r.buffer, r.next)
+			r.isPassed(r.next)
+			r.out.Push(index)
+			r.lastRelease = time.Now()
+		} else if !r.isPassed(r.next) {
+			return
+		}
+
+		r.next++
+	}
+}
+
+// skipAhead moves the next expected id to the smallest buffered one.
+func (r *resequencer) skipAhead() {
+	r.passedMu.Lock()
+	for id := range r.passed {
+		if id < r.next {
+			delete(r.passed, id)
+		}
+	}
+	r.passedMu.Unlock()
+
+	min := int64(-1)
+	for id := range r.buffer {
+		if min == -1 || id < min {
+			min = id
+		}
+	}
+
+	if min > r.next {
+		r.next = min
+	}
+
+	r.release()
+}
+
+// flush releases all buffered items in order and closes the channel of final handlers.
+func (r *resequencer) flush() {
+	ids := make([]int64, 0, len(r.buffer))
+	for id := range r.buffer {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
+
+	for _, id := range ids {
+		r.out.Push(r.buffer[id])
+	}
+
+	r.buffer = map[int64]int{}
+	r.out.Close()
+}
+
+// tickPeriod returns the period of checking timeout, a small part of it.
+func (r *resequencer) tickPeriod() time.Duration {
+	period := r.timeout / 10
+	if period < time.Millisecond {
+		period = time.Millisecond
+	}
+
+	return period
+}
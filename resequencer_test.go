@@ -0,0 +1,129 @@
+package conveyor_test
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type randomSleepHandler struct {
+	faces.EmptyHandler
+}
+
+func newRandomSleepHandler(_ faces.Name) (faces.IHandler, error) {
+	return &randomSleepHandler{}, nil
+}
+
+func (h *randomSleepHandler) Run(_ faces.IItem) error {
+	time.Sleep(time.Duration(rand.Intn(3000)) * time.Microsecond)
+
+	return nil
+}
+
+type exportHandler struct {
+	faces.EmptyHandler
+
+	sync.Mutex
+	ids []int
+}
+
+func (h *exportHandler) Run(item faces.IItem) error {
+	h.Lock()
+	defer h.Unlock()
+
+	// aggregate items of windows are not exported
+	if id, ok := item.Get().(int); ok {
+		h.ids = append(h.ids, id)
+	}
+
+	return nil
+}
+
+func (h *exportHandler) exported() []int {
+	h.Lock()
+	defer h.Unlock()
+
+	return append([]int{}, h.ids...)
+}
+
+func (s *testSuite) TestResequencer(c *C) {
+	export := &exportHandler{}
+	newExport := func(_ faces.Name) (faces.IHandler, error) {
+		return export, nil
+	}
+
+	cv := conveyor.New(50, faces.ChanStdGo, "resequencer")
+	cv.SetResequencer(50, time.Minute)
+	c.Assert(cv.AddHandler("sleep", 5, 5, newRandomSleepHandler), IsNil)
+	c.Assert(cv.AddFinalHandler("export", 3, 3, newExport), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	expected := make([]int, 0)
+	for i := 1; i <= 40; i++ {
+		cv.Run(input.New().Data(i))
+		expected = append(expected, i)
+	}
+
+	cv.WaitAndStop()
+
+	c.Assert(export.ids, DeepEquals, expected)
+}
+
+func (s *testSuite) TestResequencerWindow(c *C) {
+	export := &exportHandler{}
+	newExport := func(_ faces.Name) (faces.IHandler, error) {
+		return export, nil
+	}
+
+	// window is too small for 5 workers, so items skip ahead but all of them are finished
+	cv := conveyor.New(50, faces.ChanStdGo, "resequencer")
+	cv.SetResequencer(1, 10*time.Millisecond)
+	c.Assert(cv.AddHandler("sleep", 5, 5, newRandomSleepHandler), IsNil)
+	c.Assert(cv.AddFinalHandler("export", 1, 1, newExport), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	for i := 1; i <= 40; i++ {
+		cv.Run(input.New().Data(i))
+	}
+
+	cv.WaitAndStop()
+
+	c.Assert(len(export.ids), Equals, 40)
+}
+
+func (s *testSuite) TestResequencerHeld(c *C) {
+	export := &exportHandler{}
+	newExport := func(_ faces.Name) (faces.IHandler, error) {
+		return export, nil
+	}
+
+	cv := conveyor.New(50, faces.ChanStdGo, "resequencer")
+	cv.SetResequencer(50, time.Minute)
+	c.Assert(cv.AddWindowHandler("window", 1, 1, time.Hour, 0, customerKey, countReducer, faces.WindowAbsorb), IsNil)
+	c.Assert(cv.AddHandler("after", 1, 1, faces.MakeEmptyHandler), IsNil)
+	c.Assert(cv.AddFinalHandler("export", 1, 1, newExport), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// the first item is held by window until drain, the next ones don't wait for it
+	c.Assert(cv.Run(input.New().Data(0)), IsNil)
+	for i := 1; i <= 4; i++ {
+		c.Assert(cv.Run(input.New().Data(i).SkipToName("after")), IsNil)
+	}
+
+	for i := 0; i < 100 && len(export.exported()) < 4; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	c.Assert(export.exported(), DeepEquals, []int{1, 2, 3, 4})
+
+	cv.WaitAndStop()
+
+	c.Assert(export.exported(), DeepEquals, []int{1, 2, 3, 4, 0})
+}
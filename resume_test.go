@@ -0,0 +1,140 @@
+package conveyor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type normalizeHandler struct {
+	faces.EmptyHandler
+}
+
+func newNormalizeHandler(_ faces.Name) (faces.IHandler, error) {
+	return &normalizeHandler{}, nil
+}
+
+// Run fails the items with not positive id.
+func (h *normalizeHandler) Run(item faces.IItem) error {
+	msg := item.Get().(*pathMessage)
+	if msg.id <= 0 {
+		return errors.New("encoding error")
+	}
+
+	msg.add("normalize")
+
+	return nil
+}
+
+type repairHandler struct {
+	faces.EmptyHandler
+}
+
+func newRepairHandler(_ faces.Name) (faces.IHandler, error) {
+	return &repairHandler{}, nil
+}
+
+// Run repairs the negative id and sends item back to normalize handler.
+func (h *repairHandler) Run(item faces.IItem) error {
+	msg := item.Get().(*pathMessage)
+	msg.add("repair")
+	msg.id = -msg.id
+
+	item.Resume("normalize")
+
+	return nil
+}
+
+func (s *testSuite) TestResume(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "resume").SetMaxResumes(2)
+	c.Assert(cv.AddHandler("first", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("normalize", 1, 1, newNormalizeHandler), IsNil)
+	c.Assert(cv.AddHandler("last", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("repair", 1, 1, newRepairHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// repaired item goes on from the named handler
+	res, err := cv.RunRes(input.New().Data(&pathMessage{id: -1}))
+	c.Assert(err, IsNil)
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"first", "repair", "normalize", "last"})
+
+	// loop guard stops the item which can't be repaired
+	res, err = cv.RunRes(input.New().Data(&pathMessage{id: 0}))
+	c.Assert(err, ErrorMatches, "resume to 'normalize': resume limit 2 is exceeded")
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"first", "repair", "repair", "repair"})
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestResumeUnknown(c *C) {
+	repair := func(_ faces.Name) (faces.IHandler, error) {
+		return &resumeUnknownHandler{}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "resume")
+	c.Assert(cv.AddHandler("normalize", 1, 1, newNormalizeHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("repair", 1, 1, repair), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	_, err := cv.RunRes(input.New().Data(&pathMessage{id: 0}))
+	c.Assert(err, ErrorMatches, "resume to unknown handler 'nobody'")
+
+	cv.WaitAndStop()
+}
+
+type resumeUnknownHandler struct {
+	faces.EmptyHandler
+}
+
+func (h *resumeUnknownHandler) Run(item faces.IItem) error {
+	item.Resume("nobody")
+
+	return nil
+}
+
+type gateRepairHandler struct {
+	repairHandler
+
+	entered chan struct{}
+	release chan struct{}
+}
+
+// Run waits for release and repairs the item.
+func (h *gateRepairHandler) Run(item faces.IItem) error {
+	h.entered <- struct{}{}
+	<-h.release
+
+	return h.repairHandler.Run(item)
+}
+
+func (s *testSuite) TestResumeAfterStop(c *C) {
+	entered, release := make(chan struct{}, 1), make(chan struct{})
+	repair := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateRepairHandler{entered: entered, release: release}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "resume")
+	c.Assert(cv.AddHandler("first", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("normalize", 1, 1, newNormalizeHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("repair", 1, 1, repair), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	future, err := cv.RunAsync(input.New().Data(&pathMessage{id: -1}))
+	c.Assert(err, IsNil)
+	<-entered
+
+	// error handlers work after Stop, the input channel of "normalize" is closed
+	cv.Stop()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	res, err := future.Result()
+	c.Assert(err, ErrorMatches, "resume to 'normalize': handler is stopped")
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"first", "repair"})
+}
@@ -0,0 +1,76 @@
+package conveyor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+var errFatal = errors.New("fatal error")
+
+type flakyHandler struct {
+	faces.EmptyHandler
+}
+
+func newFlakyHandler(_ faces.Name) (faces.IHandler, error) {
+	return &flakyHandler{}, nil
+}
+
+// Run fails the first two attempts, items with negative data are failed with fatal error.
+func (h *flakyHandler) Run(item faces.IItem) error {
+	if item.Get().(int) < 0 {
+		return errFatal
+	}
+
+	if item.GetAttempts() < 3 {
+		return errors.New("temporary error")
+	}
+
+	return nil
+}
+
+func (s *testSuite) TestRetry(c *C) {
+	policy := faces.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		Jitter:      0.5,
+		Retryable: func(err error) bool {
+			return err != errFatal
+		},
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "retry")
+	c.Assert(cv.AddHandler("flaky", 2, 2, newFlakyHandler), IsNil)
+	c.Assert(cv.SetRetry("flaky", policy), IsNil)
+	c.Assert(cv.SetRetry("unknown", policy), NotNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	res, err := cv.RunRes(input.New().Data(1))
+	c.Assert(err, IsNil)
+	c.Assert(res, Equals, 1)
+
+	// fatal error is not retried
+	_, err = cv.RunRes(input.New().Data(-1))
+	c.Assert(err, Equals, errFatal)
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestRetryExhausted(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "retry")
+	c.Assert(cv.AddHandler("flaky", 1, 1, newFlakyHandler), IsNil)
+	c.Assert(cv.SetRetry("flaky", faces.RetryPolicy{MaxAttempts: 2}), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	_, err := cv.RunRes(input.New().Data(1))
+	c.Assert(err, ErrorMatches, "temporary error")
+
+	cv.WaitAndStop()
+}
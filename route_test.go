@@ -0,0 +1,168 @@
+package conveyor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type routeHandler struct {
+	pathHandler
+
+	to     faces.Name
+	routes int
+}
+
+func routeBack(to faces.Name, routes int) faces.GiveBirth {
+	return func(name faces.Name) (faces.IHandler, error) {
+		return &routeHandler{pathHandler: pathHandler{name: name}, to: to, routes: routes}, nil
+	}
+}
+
+func (h *routeHandler) Run(item faces.IItem) error {
+	_ = h.pathHandler.Run(item)
+
+	if item.GetHops() < h.routes {
+		item.RouteTo(h.to)
+	}
+
+	return nil
+}
+
+func (s *testSuite) TestRouteTo(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "route")
+
+	c.Assert(cv.AddHandler("pre", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("ocr", 1, 2, routeBack("pre", 2)), IsNil)
+	c.Assert(cv.AddHandler("post", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	for i := 0; i < 5; i++ {
+		res, err := cv.RunRes(input.New().Data(&pathMessage{}))
+		c.Assert(err, IsNil)
+		c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"pre", "ocr", "pre", "ocr", "pre", "ocr", "post"})
+	}
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestRouteToErrors(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "route").SetMaxHops(3)
+
+	c.Assert(cv.AddHandler("a", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("b", 1, 2, routeBack("a", 100)), IsNil)
+	c.Assert(cv.AddHandler("c", 1, 2, routeBack("nobody", 100)), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	res, err := cv.RunRes(input.New().Data(&pathMessage{}))
+	c.Assert(err, ErrorMatches, "route to 'a': hop limit 3 is exceeded")
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"a", "b", "a", "b", "a", "b", "a", "b"})
+
+	res, err = cv.RunRes(input.New().Data(&pathMessage{}).SkipToName("c"))
+	c.Assert(err, ErrorMatches, "route to unknown handler 'nobody'")
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"c"})
+
+	cv.WaitAndStop()
+}
+
+type routeFailHandler struct {
+	pathHandler
+}
+
+func newRouteFailHandler(name faces.Name) (faces.IHandler, error) {
+	return &routeFailHandler{pathHandler: pathHandler{name: name}}, nil
+}
+
+// Run routes the items with not positive id back to the first handler and fails them.
+func (h *routeFailHandler) Run(item faces.IItem) error {
+	_ = h.pathHandler.Run(item)
+
+	if item.Get().(*pathMessage).id <= 0 {
+		item.RouteTo("first")
+
+		return errors.New("bad id")
+	}
+
+	return nil
+}
+
+type resumeLastHandler struct {
+	pathHandler
+}
+
+func (h *resumeLastHandler) Run(item faces.IItem) error {
+	_ = h.pathHandler.Run(item)
+	item.Resume("last")
+
+	return nil
+}
+
+func (s *testSuite) TestRouteToFailed(c *C) {
+	repair := func(name faces.Name) (faces.IHandler, error) {
+		return &resumeLastHandler{pathHandler: pathHandler{name: name}}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "route")
+	c.Assert(cv.AddHandler("first", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("check", 1, 1, newRouteFailHandler), IsNil)
+	c.Assert(cv.AddHandler("last", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("repair", 1, 1, repair), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// the route of failed run is not used after resume
+	res, err := cv.RunRes(input.New().Data(&pathMessage{}))
+	c.Assert(err, IsNil)
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"first", "check", "repair", "last"})
+
+	cv.WaitAndStop()
+}
+
+type gateRouteHandler struct {
+	pathHandler
+
+	to      faces.Name
+	entered chan struct{}
+	release chan struct{}
+}
+
+// Run waits for release and routes the item back.
+func (h *gateRouteHandler) Run(item faces.IItem) error {
+	_ = h.pathHandler.Run(item)
+	h.entered <- struct{}{}
+	<-h.release
+	item.RouteTo(h.to)
+
+	return nil
+}
+
+func (s *testSuite) TestRouteToAfterStop(c *C) {
+	entered, release := make(chan struct{}, 1), make(chan struct{})
+	gate := func(name faces.Name) (faces.IHandler, error) {
+		return &gateRouteHandler{pathHandler: pathHandler{name: name}, to: "a", entered: entered, release: release}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "route")
+	c.Assert(cv.AddHandler("p", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("a", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("b", 1, 1, gate), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	future, err := cv.RunAsync(input.New().Data(&pathMessage{}))
+	c.Assert(err, IsNil)
+	<-entered
+
+	// the input channel of "a" is closed when "p" is stopped
+	cv.Stop()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	res, err := future.Result()
+	c.Assert(err, ErrorMatches, "route to 'a': handler is stopped")
+	c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"p", "a", "b"})
+}
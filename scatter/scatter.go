@@ -0,0 +1,230 @@
+/*
+Package scatter implements the handler which hands one item to several handlers at the same time.
+Each handler sets its own result slot, the results are joined into the item data when all handlers are finished.
+The item goes on when all handlers are finished, the errors of failed handlers are merged.
+*/
+package scatter
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+// Errors is a list of errors returned by the handlers of one stage.
+type Errors []error
+
+// Error implements the error interface.
+func (e Errors) Error() string {
+	out := make([]string, len(e))
+	for i, err := range e {
+		out[i] = err.Error()
+	}
+
+	return strings.Join(out, "; ")
+}
+
+// Unwrap returns the merged errors, it allows errors.Is and errors.As to look inside.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// minTickerDuration limits the ticker of scatter handler when the periods of handlers have a tiny common divisor.
+const minTickerDuration = time.Millisecond
+
+// Handler implements the faces.IHandler interface over the list of handlers.
+type Handler struct {
+	handlers []faces.IHandler
+	join     faces.JoinFunc
+
+	// the time of the next ticker run for each handler, zero if handler has no ticker
+	tickerMu   sync.Mutex
+	tickerNext []time.Time
+}
+
+// JoinResults is the default faces.JoinFunc, the new data of item is the list of results in order of handlers.
+func JoinResults(_ interface{}, results []interface{}) interface{} {
+	return results
+}
+
+// New returns the GiveBirth function which creates Handler from the list of handlers.
+// Join makes the new data of item from the results of handlers, JoinResults is used if it's nil.
+func New(join faces.JoinFunc, births ...faces.GiveBirth) faces.GiveBirth {
+	if join == nil {
+		join = JoinResults
+	}
+
+	return func(name faces.Name) (faces.IHandler, error) {
+		if len(births) == 0 {
+			return nil, errors.New("scatter handler '" + string(name) + "' has no handlers")
+		}
+
+		h := &Handler{
+			join:       join,
+			handlers:   make([]faces.IHandler, 0, len(births)),
+			tickerNext: make([]time.Time, len(births)),
+		}
+
+		for _, giveBirth := range births {
+			handler, err := giveBirth(name)
+			if err != nil {
+				return nil, err
+			}
+
+			h.handlers = append(h.handlers, handler)
+		}
+
+		return h, nil
+	}
+}
+
+// Start starts all handlers. Already started handlers are stopped if one of them returns error.
+func (h *Handler) Start(ctx context.Context) error {
+	for i, handler := range h.handlers {
+		if err := handler.Start(ctx); err != nil {
+			for _, started := range h.handlers[:i] {
+				started.Stop(ctx)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop stops all handlers.
+func (h *Handler) Stop(ctx context.Context) {
+	for _, handler := range h.handlers {
+		handler.Stop(ctx)
+	}
+}
+
+// TickerRun calls TickerRun for handlers which ticker periods have passed since their previous run.
+func (h *Handler) TickerRun(ctx context.Context) {
+	h.tickerMu.Lock()
+	defer h.tickerMu.Unlock()
+
+	now, tick := time.Now(), h.TickerDuration()
+	for i, handler := range h.handlers {
+		dur := handler.TickerDuration()
+		if dur == time.Duration(0) {
+			continue
+		}
+
+		// the first call starts the period
+		if h.tickerNext[i].IsZero() {
+			h.tickerNext[i] = now.Add(dur - tick)
+		}
+
+		// half of the scatter ticker absorbs the jitter of time.Ticker
+		if now.Add(tick / 2).Before(h.tickerNext[i]) {
+			continue
+		}
+
+		handler.TickerRun(ctx)
+		h.tickerNext[i] = h.tickerNext[i].Add(dur)
+		if h.tickerNext[i].Before(now) {
+			h.tickerNext[i] = now.Add(dur)
+		}
+	}
+}
+
+// TickerDuration returns the greatest common divisor of non-zero ticker durations from all handlers,
+// so each handler runs with its own period, see TickerRun. It's not less than minTickerDuration.
+func (h *Handler) TickerDuration() time.Duration {
+	out := time.Duration(0)
+	for _, handler := range h.handlers {
+		if dur := handler.TickerDuration(); dur != time.Duration(0) {
+			out = gcd(out, dur)
+		}
+	}
+
+	if out != time.Duration(0) && out < minTickerDuration {
+		out = minTickerDuration
+	}
+
+	return out
+}
+
+func gcd(a, b time.Duration) time.Duration {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+// slotItem is the item for single handler, its data is the result slot of handler.
+// Other methods are shared with the item.
+type slotItem struct {
+	faces.IItem
+
+	mu   sync.Mutex
+	data interface{}
+}
+
+// Get returns the result of handler, it's the data of item until the handler sets it.
+func (s *slotItem) Get() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.data
+}
+
+// Set sets up the result of handler, the item data is not changed.
+func (s *slotItem) Set(data interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = data
+}
+
+func runOne(res chan error, handler faces.IHandler, item faces.IItem) {
+	defer func() {
+		if e := recover(); e != nil {
+			res <- errors.WithStack(&faces.ErrPanic{Value: e})
+		}
+	}()
+
+	res <- handler.Run(item)
+}
+
+// Run hands the item to all handlers at the same time and waits for all of them,
+// so no handler changes the item after it has gone further. Each handler gets own result slot,
+// the slots are joined into the item data if all handlers are successful. The errors of failed handlers are merged.
+func (h *Handler) Run(item faces.IItem) error {
+	data := item.Get()
+
+	slots := make([]*slotItem, len(h.handlers))
+	res := make(chan error, len(h.handlers))
+	for i, handler := range h.handlers {
+		slots[i] = &slotItem{IItem: item, data: data}
+		go runOne(res, handler, slots[i])
+	}
+
+	errs := Errors{}
+	for range h.handlers {
+		if err := <-res; err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	results := make([]interface{}, len(slots))
+	for i, slot := range slots {
+		results[i] = slot.Get()
+	}
+
+	item.Set(h.join(data, results))
+
+	return nil
+}
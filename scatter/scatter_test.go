@@ -0,0 +1,188 @@
+package scatter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/item"
+	"github.com/iostrovok/conveyor/scatter"
+)
+
+type testSuite struct{}
+
+var _ = Suite(&testSuite{})
+
+func TestService(t *testing.T) { TestingT(t) }
+
+type sleepHandler struct {
+	faces.EmptyHandler
+
+	sleep time.Duration
+	err   error
+}
+
+func sleeper(sleep time.Duration, err error) faces.GiveBirth {
+	return func(_ faces.Name) (faces.IHandler, error) {
+		return &sleepHandler{sleep: sleep, err: err}, nil
+	}
+}
+
+func (h *sleepHandler) Run(_ faces.IItem) error {
+	time.Sleep(h.sleep)
+
+	if h.err != nil && h.err.Error() == "panic" {
+		panic(h.err)
+	}
+
+	return h.err
+}
+
+func run(c *C, births ...faces.GiveBirth) (error, time.Duration) {
+	h, err := scatter.New(nil, births...)("scatter")
+	c.Assert(err, IsNil)
+	c.Assert(h.Start(context.Background()), IsNil)
+	defer h.Stop(context.Background())
+
+	start := time.Now()
+	err = h.Run(item.New(context.Background(), nil))
+
+	return err, time.Since(start)
+}
+
+func (s *testSuite) TestParallel(c *C) {
+	err, dur := run(c, sleeper(100*time.Millisecond, nil), sleeper(100*time.Millisecond, nil),
+		sleeper(100*time.Millisecond, nil))
+
+	c.Assert(err, IsNil)
+	c.Assert(dur < 250*time.Millisecond, Equals, true)
+}
+
+func (s *testSuite) TestWaitsAll(c *C) {
+	err, dur := run(c, sleeper(100*time.Millisecond, nil), sleeper(10*time.Millisecond, errors.New("fail")))
+
+	c.Assert(err, ErrorMatches, "fail")
+	c.Assert(dur >= 100*time.Millisecond, Equals, true)
+}
+
+func (s *testSuite) TestMergedErrors(c *C) {
+	err, _ := run(c, sleeper(0, errors.New("first")), sleeper(0, errors.New("panic")))
+
+	errs, ok := err.(scatter.Errors)
+	c.Assert(ok, Equals, true)
+	c.Assert(errs, HasLen, 2)
+}
+
+func (s *testSuite) TestUnwrap(c *C) {
+	target := errors.New("target")
+	err, _ := run(c, sleeper(0, errors.New("first")), sleeper(0, errors.Wrap(target, "second")))
+
+	c.Assert(errors.Is(err, target), Equals, true)
+
+	var panicErr *faces.ErrPanic
+	c.Assert(errors.As(err, &panicErr), Equals, false)
+}
+
+type addHandler struct {
+	faces.EmptyHandler
+
+	add int
+}
+
+func adder(add int) faces.GiveBirth {
+	return func(_ faces.Name) (faces.IHandler, error) {
+		return &addHandler{add: add}, nil
+	}
+}
+
+func (h *addHandler) Run(item faces.IItem) error {
+	time.Sleep(10 * time.Millisecond)
+	item.Set(item.Get().(int) + h.add)
+
+	return nil
+}
+
+func (s *testSuite) TestResults(c *C) {
+	h, err := scatter.New(nil, adder(1), adder(10), adder(100))("scatter")
+	c.Assert(err, IsNil)
+
+	it := item.New(context.Background(), nil)
+	it.Set(5)
+	c.Assert(h.Run(it), IsNil)
+	c.Assert(it.Get(), DeepEquals, []interface{}{6, 15, 105})
+}
+
+func (s *testSuite) TestJoin(c *C) {
+	sum := func(data interface{}, results []interface{}) interface{} {
+		out := data.(int)
+		for _, r := range results {
+			out += r.(int) - data.(int)
+		}
+
+		return out
+	}
+
+	h, err := scatter.New(sum, adder(1), adder(10), adder(100))("scatter")
+	c.Assert(err, IsNil)
+
+	it := item.New(context.Background(), nil)
+	it.Set(5)
+	c.Assert(h.Run(it), IsNil)
+	c.Assert(it.Get(), Equals, 116)
+}
+
+func (s *testSuite) TestErrorKeepsData(c *C) {
+	h, err := scatter.New(nil, adder(1), sleeper(0, errors.New("fail")))("scatter")
+	c.Assert(err, IsNil)
+
+	it := item.New(context.Background(), nil)
+	it.Set(5)
+	c.Assert(h.Run(it), ErrorMatches, "fail")
+	c.Assert(it.Get(), Equals, 5)
+}
+
+type tickerHandler struct {
+	faces.EmptyHandler
+
+	period time.Duration
+	runs   int
+}
+
+func (h *tickerHandler) TickerDuration() time.Duration {
+	return h.period
+}
+
+func (h *tickerHandler) TickerRun(_ context.Context) {
+	h.runs++
+}
+
+func (s *testSuite) TestTickerPeriods(c *C) {
+	fast, slow := &tickerHandler{period: 20 * time.Millisecond}, &tickerHandler{period: 60 * time.Millisecond}
+	h, err := scatter.New(nil,
+		func(_ faces.Name) (faces.IHandler, error) { return fast, nil },
+		func(_ faces.Name) (faces.IHandler, error) { return slow, nil },
+		sleeper(0, nil),
+	)("scatter")
+	c.Assert(err, IsNil)
+	c.Assert(h.TickerDuration(), Equals, 20*time.Millisecond)
+
+	ticker := time.NewTicker(h.TickerDuration())
+	defer ticker.Stop()
+
+	for i := 0; i < 30; i++ {
+		<-ticker.C
+		h.TickerRun(context.Background())
+	}
+
+	c.Assert(fast.runs, Equals, 30)
+	c.Assert(slow.runs >= 9 && slow.runs <= 10, Equals, true)
+}
+
+func (s *testSuite) TestEmpty(c *C) {
+	_, err := scatter.New(nil)("scatter")
+	c.Assert(err, NotNil)
+}
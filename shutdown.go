@@ -0,0 +1,82 @@
+package conveyor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+// Shutdown stops taking new items and waits while the taken items are processed, like WaitAndStop does,
+// but not longer than the context is done. After that the submissions which are waiting for a room are rejected
+// with faces.ErrStopping, the items which are still in conveyor are canceled (see Cancel) and Shutdown returns
+// *faces.ErrShutdownTimeout with their statuses without waiting for them. Items held by windows and split parents
+// which are waiting for children are sent to the error handlers at once.
+func (c *Conveyor) Shutdown(ctx context.Context) error {
+	if err := c.startDrain(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.drain()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	// no items come after the report
+	c.data.cancelSubmits()
+	c.data.cancelWindows()
+	c.data.submits.Wait()
+
+	report := &faces.ErrShutdownTimeout{Cause: ctx.Err()}
+	reason := errors.Wrap(ctx.Err(), "conveyor is shut down")
+
+	for _, it := range c.data.workBench.Items() {
+		report.Unfinished = append(report.Unfinished, it.Status())
+		cancelItem(it, reason)
+	}
+
+	// items which are kept by conveyor itself are not processed by handlers more
+	for _, index := range c.keptItems() {
+		c.abandon(index, reason)
+	}
+
+	if len(report.Unfinished) == 0 {
+		return nil
+	}
+
+	return report
+}
+
+// keptItems returns the indexes of items which are held by windows and split parents and forgets them.
+func (c *Conveyor) keptItems() []int {
+	out := make([]int, 0)
+	for _, s := range c.data.windows {
+		out = append(out, s.cancel()...)
+	}
+
+	if c.data.splitter != nil {
+		out = append(out, c.data.splitter.cancel()...)
+	}
+
+	return out
+}
+
+// abandon sends the canceled item to the error handlers with the reason.
+func (c *Conveyor) abandon(index int, reason error) {
+	it, err := c.data.workBench.Get(index)
+	if err != nil || it == nil {
+		return
+	}
+
+	it.AddError(reason)
+	it.PushedToChannel(faces.ErrorName)
+	c.data.errCh.Push(index)
+}
@@ -0,0 +1,204 @@
+package conveyor_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+func (s *testSuite) TestShutdown(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "shutdown")
+	c.Assert(cv.Shutdown(context.Background()), Equals, faces.ErrNotStarted)
+
+	c.Assert(cv.AddHandler("first", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	futures := make([]faces.IFuture, 0, 5)
+	for i := 0; i < 5; i++ {
+		f, err := cv.RunAsync(input.New().Data(&pathMessage{}))
+		c.Assert(err, IsNil)
+
+		futures = append(futures, f)
+	}
+
+	c.Assert(cv.Shutdown(context.Background()), IsNil)
+	c.Assert(cv.State(), Equals, faces.ConveyorStopped)
+	c.Assert(cv.Shutdown(context.Background()), Equals, faces.ErrStopping)
+
+	for _, f := range futures {
+		_, err := f.Result()
+		c.Assert(err, IsNil)
+	}
+}
+
+func (s *testSuite) TestShutdownDeadline(c *C) {
+	ids := make(chan int64, 2)
+	newLongHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &longHandler{ids: ids}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "shutdown-deadline")
+	c.Assert(cv.AddHandler("long", 2, 2, newLongHandler), IsNil)
+	c.Assert(cv.AddErrorHandler("errors", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	futures := map[int64]faces.IFuture{}
+	for i := 0; i < 2; i++ {
+		f, err := cv.RunAsync(input.New().Data(&pathMessage{}))
+		c.Assert(err, IsNil)
+
+		futures[f.ID()] = f
+	}
+
+	<-ids
+	<-ids
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := cv.Shutdown(ctx)
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+
+	var report *faces.ErrShutdownTimeout
+	c.Assert(errors.As(err, &report), Equals, true)
+	c.Assert(report.Unfinished, HasLen, 2)
+	c.Assert(err, ErrorMatches, "conveyor is shut down with 2 unfinished items: .*")
+
+	for _, status := range report.Unfinished {
+		c.Assert(status.Stage, Equals, faces.Name("long"))
+		c.Assert(status.State, Equals, faces.ItemProcessing)
+
+		f, find := futures[status.ID]
+		c.Assert(find, Equals, true)
+
+		// canceled items go through the error and final handlers
+		res, err := f.Result()
+		c.Assert(err, ErrorMatches, "conveyor is shut down: context deadline exceeded")
+		c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"errors"})
+	}
+
+	for cv.State() != faces.ConveyorStopped {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *testSuite) TestShutdownWindow(c *C) {
+	release := make(chan struct{})
+	items := make(chan faces.IItem, 1)
+	newHangHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &hangHandler{release: release, items: items}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "shutdown-window")
+	c.Assert(cv.AddHandler("hang", 1, 2, newHangHandler), IsNil)
+	c.Assert(cv.AddWindowHandler("window", 1, 1, time.Hour, 0, customerKey, countReducer, faces.WindowAbsorb), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	held, err := cv.RunAsync(input.New().Data(1))
+	c.Assert(err, IsNil)
+
+	for {
+		if status := waitStage(c, cv, held.ID(), "window"); status.State == faces.ItemWaiting {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	// the hung item doesn't let the windows be flushed
+	c.Assert(cv.Run(input.New().Data(-1)), IsNil)
+	<-items
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var report *faces.ErrShutdownTimeout
+	c.Assert(errors.As(cv.Shutdown(ctx), &report), Equals, true)
+	c.Assert(report.Unfinished, HasLen, 2)
+
+	// the item held by window goes to the error handlers
+	_, err = held.Result()
+	c.Assert(err, ErrorMatches, "conveyor is shut down: context deadline exceeded")
+
+	close(release)
+
+	for cv.State() != faces.ConveyorStopped {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *testSuite) TestShutdownSplit(c *C) {
+	ids, release := make(chan int64, 2), make(chan struct{})
+	newGateHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateHandler{ids: ids, release: release}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "shutdown-split")
+	c.Assert(cv.AddHandler("split", 1, 1, newSplitHandler), IsNil)
+	c.Assert(cv.AddHandler("gate", 2, 2, newGateHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	f, err := cv.RunAsync(input.New().Data(2))
+	c.Assert(err, IsNil)
+
+	<-ids
+	<-ids
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var report *faces.ErrShutdownTimeout
+	c.Assert(errors.As(cv.Shutdown(ctx), &report), Equals, true)
+	c.Assert(report.Unfinished, HasLen, 3)
+
+	// the parent doesn't wait for children which are processed yet
+	_, err = f.Result()
+	c.Assert(err, ErrorMatches, "conveyor is shut down: context deadline exceeded")
+
+	close(release)
+
+	for cv.State() != faces.ConveyorStopped {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (s *testSuite) TestShutdownPending(c *C) {
+	ids, release := make(chan int64, 1), make(chan struct{})
+	newGateHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateHandler{ids: ids, release: release}, nil
+	}
+
+	cv := conveyor.New(1, faces.ChanStdGo, "shutdown-pending")
+	c.Assert(cv.AddHandler("gate", 1, 1, newGateHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	c.Assert(cv.Run(input.New().Data(1)), IsNil)
+	<-ids
+
+	// the submission waits for the single place of work bench
+	pending := make(chan error, 1)
+	go func() {
+		pending <- cv.Run(input.New().Data(2))
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var report *faces.ErrShutdownTimeout
+	c.Assert(errors.As(cv.Shutdown(ctx), &report), Equals, true)
+	c.Assert(report.Unfinished, HasLen, 1)
+	c.Assert(<-pending, Equals, faces.ErrStopping)
+
+	close(release)
+
+	for cv.State() != faces.ConveyorStopped {
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,139 @@
+package conveyor
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/item"
+	"github.com/iostrovok/conveyor/scatter"
+)
+
+// splitter creates the child items and sends the parent to the final handlers after the last of them.
+// It supports the faces.ISplitter interface.
+type splitter struct {
+	sync.Mutex
+
+	conveyor *Conveyor
+
+	// parent id => waiting parent
+	parents map[int64]*splitParent
+}
+
+type splitParent struct {
+	index     int
+	remaining int
+}
+
+func newSplitter(c *Conveyor) *splitter {
+	return &splitter{
+		conveyor: c,
+		parents:  map[int64]*splitParent{},
+	}
+}
+
+// Split creates the child items with own IDs. Children inherit the context, tracer, priority, key and test object of parent.
+// The places in work bench are reserved for all children at once without waiting, the worker which splits the parent
+// can't wait for them: the places are freed by the same workers. The parent fails if there is no room for all children.
+func (s *splitter) Split(index int, parent faces.IItem, data []interface{}) ([]int, error) {
+	c := s.conveyor
+
+	children := make([]faces.IItem, 0, len(data))
+	out := make([]int, 0, len(data))
+	for _, d := range data {
+		child := item.New(parent.GetContext(), parent.GetTrace())
+		child.SetPriority(parent.GetPriority())
+		child.SetKey(parent.GetKey())
+		child.SetTestObject(parent.GetTestObject())
+		child.Set(d)
+		child.SetParent(parent)
+
+		i, ok := c.data.workBench.TryAdd(child)
+		if !ok {
+			for _, reserved := range out {
+				c.data.workBench.Clean(reserved)
+			}
+
+			return nil, errors.Wrapf(faces.ErrConveyorFull, "split to %d children", len(data))
+		}
+
+		children = append(children, child)
+		out = append(out, i)
+	}
+
+	s.Lock()
+	s.parents[parent.GetID()] = &splitParent{index: index, remaining: len(data)}
+	s.Unlock()
+
+	// the parent comes to the final handlers after children, the next items don't wait for it
+	if c.data.resequencer != nil {
+		c.data.resequencer.pass(parent.GetID())
+	}
+
+	// ids are taken when all places are reserved, the failed split makes no gaps for resequencer
+	for _, child := range children {
+		c.numerate(child)
+		parent.AddChild(child)
+		child.Start()
+	}
+
+	return out, nil
+}
+
+// Done marks the child as finished. The parent gets the list of children's results
+// and the joined errors of them and goes to the final handlers after the last child.
+func (s *splitter) Done(child faces.IItem) {
+	parent := child.GetParent()
+
+	s.Lock()
+	p, find := s.parents[parent.GetID()]
+	if !find {
+		s.Unlock()
+
+		return
+	}
+
+	p.remaining--
+	if p.remaining > 0 {
+		s.Unlock()
+
+		return
+	}
+
+	delete(s.parents, parent.GetID())
+	s.Unlock()
+
+	children := parent.GetChildren()
+	results := make([]interface{}, len(children))
+	errs := make(scatter.Errors, 0)
+	for k, ch := range children {
+		results[k] = ch.Get()
+		if err := ch.GetError(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	parent.Set(results)
+	if len(errs) > 0 {
+		parent.AddError(errs)
+	}
+
+	parent.PushedToChannel(defaultFinalName)
+	s.conveyor.data.outCh.Push(p.index)
+}
+
+// cancel forgets all waiting parents and returns their indexes in work bench.
+// The children which are finished later don't send the parents further.
+func (s *splitter) cancel() []int {
+	s.Lock()
+	defer s.Unlock()
+
+	out := make([]int, 0, len(s.parents))
+	for id, p := range s.parents {
+		out = append(out, p.index)
+		delete(s.parents, id)
+	}
+
+	return out
+}
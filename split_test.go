@@ -0,0 +1,94 @@
+package conveyor_test
+
+import (
+	"context"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type splitHandler struct {
+	faces.EmptyHandler
+}
+
+func newSplitHandler(_ faces.Name) (faces.IHandler, error) {
+	return &splitHandler{}, nil
+}
+
+// Run splits the N to 1..N numbers.
+func (h *splitHandler) Run(item faces.IItem) error {
+	for i := 1; i <= item.Get().(int); i++ {
+		item.Split(i)
+	}
+
+	return nil
+}
+
+type squareHandler struct {
+	faces.EmptyHandler
+}
+
+func newSquareHandler(_ faces.Name) (faces.IHandler, error) {
+	return &squareHandler{}, nil
+}
+
+func (h *squareHandler) Run(item faces.IItem) error {
+	x := item.Get().(int)
+	if x == 13 {
+		return errors.New("unlucky number")
+	}
+
+	item.Set(x * x)
+
+	return nil
+}
+
+func (s *testSuite) TestSplit(c *C) {
+	cv := conveyor.New(50, faces.ChanStdGo, "split")
+	c.Assert(cv.AddHandler("split", 1, 2, newSplitHandler), IsNil)
+	c.Assert(cv.AddHandler("square", 1, 3, newSquareHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	for n := 1; n <= 5; n++ {
+		res, err := cv.RunRes(input.New().Data(n))
+		c.Assert(err, IsNil)
+
+		expected := make([]interface{}, n)
+		for i := range expected {
+			expected[i] = (i + 1) * (i + 1)
+		}
+
+		c.Assert(res, DeepEquals, expected)
+	}
+
+	res, err := cv.RunRes(input.New().Data(14))
+	c.Assert(err, ErrorMatches, ".*unlucky number.*")
+	c.Assert(res.([]interface{})[13], Equals, 14*14)
+
+	cv.WaitAndStop()
+	c.Assert(cv.WorkBench().Count(), Equals, 0)
+}
+
+func (s *testSuite) TestSplitNoRoom(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "split")
+	c.Assert(cv.AddHandler("split", 1, 2, newSplitHandler), IsNil)
+	c.Assert(cv.AddHandler("square", 1, 3, newSquareHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// the parent fails instead of waiting for places which are never freed
+	res, err := cv.RunRes(input.New().Data(20))
+	c.Assert(errors.Is(err, faces.ErrConveyorFull), Equals, true)
+	c.Assert(err, ErrorMatches, "split to 20 children: conveyor is full")
+	c.Assert(res, Equals, 20)
+
+	res, err = cv.RunRes(input.New().Data(5))
+	c.Assert(err, IsNil)
+	c.Assert(res, HasLen, 5)
+
+	cv.WaitAndStop()
+	c.Assert(cv.WorkBench().Count(), Equals, 0)
+}
@@ -0,0 +1,138 @@
+package conveyor_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+// checkSubmission checks that all submission methods return the error.
+func checkSubmission(c *C, cv faces.IConveyor, err error) {
+	c.Assert(cv.Run(input.New().Data(1)), Equals, err)
+
+	_, e := cv.RunRes(input.New().Data(1))
+	c.Assert(e, Equals, err)
+
+	_, e = cv.RunAsync(input.New().Data(1))
+	c.Assert(e, Equals, err)
+
+	_, e = cv.TryRun(input.New().Data(1))
+	c.Assert(e, Equals, err)
+
+	_, e = cv.RunContext(context.Background(), input.New().Data(1))
+	c.Assert(e, Equals, err)
+}
+
+func (s *testSuite) TestState(c *C) {
+	busy := make(chan int64, 1)
+	release := make(chan struct{})
+	newGateHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateHandler{ids: busy, release: release}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "state")
+	c.Assert(cv.AddHandler("gate", 1, 1, newGateHandler), IsNil)
+
+	c.Assert(cv.State(), Equals, faces.ConveyorCreated)
+	checkSubmission(c, cv, faces.ErrNotStarted)
+
+	c.Assert(cv.Start(context.Background()), IsNil)
+	c.Assert(cv.State(), Equals, faces.ConveyorStarted)
+
+	c.Assert(cv.Run(input.New().Data(1)), IsNil)
+	<-busy
+
+	stopped := make(chan struct{})
+	go func() {
+		cv.WaitAndStop()
+		close(stopped)
+	}()
+
+	for cv.State() != faces.ConveyorDraining {
+		time.Sleep(time.Millisecond)
+	}
+
+	checkSubmission(c, cv, faces.ErrStopping)
+
+	close(release)
+	<-stopped
+
+	c.Assert(cv.State(), Equals, faces.ConveyorStopped)
+	checkSubmission(c, cv, faces.ErrStopping)
+	c.Assert(cv.Start(context.Background()), Equals, faces.ErrStopping)
+}
+
+func (s *testSuite) TestStateShutdownRace(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "state-race")
+	c.Assert(cv.AddHandler("first", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				if err := cv.Run(input.New().Data(&pathMessage{})); err != nil {
+					c.Check(err, Equals, faces.ErrStopping)
+
+					return
+				}
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	cv.WaitAndStop()
+	wg.Wait()
+
+	c.Assert(cv.State(), Equals, faces.ConveyorStopped)
+	c.Assert(cv.WorkBench().Count(), Equals, 0)
+}
+
+func (s *testSuite) TestStateStopDraining(c *C) {
+	busy := make(chan int64, 1)
+	release := make(chan struct{})
+	newGateHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateHandler{ids: busy, release: release}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "state")
+	c.Assert(cv.AddHandler("gate", 1, 1, newGateHandler), IsNil)
+	c.Assert(cv.AddHandler("tail", 1, 1, newPathHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	c.Assert(cv.Run(input.New().Data(&pathMessage{})), IsNil)
+	<-busy
+
+	stopped := make(chan struct{})
+	go func() {
+		cv.WaitAndStop()
+		close(stopped)
+	}()
+
+	for cv.State() != faces.ConveyorDraining {
+		time.Sleep(time.Millisecond)
+	}
+
+	// the item is not processed by stopped tail handler, draining doesn't wait for it
+	cv.Stop()
+	c.Assert(cv.State(), Equals, faces.ConveyorStopped)
+	close(release)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		c.Fatal("draining conveyor is not stopped by Stop")
+	}
+
+	c.Assert(cv.State(), Equals, faces.ConveyorStopped)
+}
@@ -0,0 +1,95 @@
+package conveyor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type gateHandler struct {
+	faces.EmptyHandler
+
+	ids     chan int64
+	release chan struct{}
+}
+
+// Run reports the id of item and waits for release.
+func (h *gateHandler) Run(item faces.IItem) error {
+	h.ids <- item.GetID()
+	<-h.release
+
+	return nil
+}
+
+// waitStage waits for item comes to the stage and returns its status.
+func waitStage(c *C, cv faces.IConveyor, id int64, stage faces.Name) *faces.ItemStatus {
+	for i := 0; i < 100; i++ {
+		status, find := cv.ItemStatus(id)
+		c.Assert(find, Equals, true)
+
+		if status.Stage == stage {
+			return status
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	c.Fatalf("item %d doesn't come to stage %s", id, stage)
+
+	return nil
+}
+
+func (s *testSuite) TestItemStatus(c *C) {
+	received, busy := make(chan int64, 2), make(chan int64, 2)
+	release := make(chan struct{})
+	newIDHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &idHandler{ids: received}, nil
+	}
+	newGateHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateHandler{ids: busy, release: release}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "status")
+	c.Assert(cv.AddHandler("first", 1, 1, newIDHandler), IsNil)
+	c.Assert(cv.AddHandler("gate", 1, 1, newGateHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	_, find := cv.ItemStatus(-1)
+	c.Assert(find, Equals, false)
+
+	cv.Run(input.New().Data(1).Priority(5))
+	cv.Run(input.New().Data(2).SkipToName("first"))
+
+	ids := []int64{<-received, <-received}
+	inWork := <-busy
+
+	for _, id := range ids {
+		status := waitStage(c, cv, id, "gate")
+		c.Assert(status.ID, Equals, id)
+		c.Assert(status.Stage, Equals, faces.Name("gate"))
+		c.Assert(status.Error, IsNil)
+		c.Assert(status.Stopped, Equals, false)
+		c.Assert(len(status.Stages), Equals, 2)
+		c.Assert(status.Stages[0].Name, Equals, faces.Name("first"))
+		c.Assert(status.Stages[1].Name, Equals, faces.Name("gate"))
+
+		if id == inWork {
+			c.Assert(status.State, Equals, faces.ItemProcessing)
+			c.Assert(status.Stages[1].Processed > 0, Equals, true)
+		} else {
+			c.Assert(status.State, Equals, faces.ItemQueued)
+			c.Assert(status.Stages[1].Queued > 0, Equals, true)
+		}
+	}
+
+	close(release)
+	cv.WaitAndStop()
+
+	_, find = cv.ItemStatus(inWork)
+	c.Assert(find, Equals, false)
+}
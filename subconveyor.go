@@ -0,0 +1,81 @@
+package conveyor
+
+import (
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+	"github.com/iostrovok/conveyor/protobuf/go/nodes"
+)
+
+// SubConveyorHandler runs the item through the whole inner conveyor as single stage.
+type SubConveyorHandler struct {
+	faces.EmptyHandler
+
+	inner faces.IConveyor
+}
+
+type subConveyor struct {
+	name  faces.Name
+	inner faces.IConveyor
+}
+
+// AsHandler returns the GiveBirth function which runs items through the inner conveyor.
+// The inner conveyor should be started and stopped by caller, AddSubConveyor does it automatically.
+func AsHandler(inner faces.IConveyor) faces.GiveBirth {
+	return func(_ faces.Name) (faces.IHandler, error) {
+		return &SubConveyorHandler{inner: inner}, nil
+	}
+}
+
+// Run sends the data of item to the inner conveyor and writes the result and error back to item.
+// Context, tracer and priority of item are passed to the inner conveyor.
+func (h *SubConveyorHandler) Run(it faces.IItem) error {
+	// data may be the item (see item.Item inheritance), the inner conveyor uses it as own item
+	// and changes its ID, status, errors and so on. The state is restored when it leaves the inner conveyor.
+	restore := func() {}
+	if self, ok := it.Get().(faces.IItem); ok {
+		restore = self.Snapshot()
+	}
+
+	in := input.New().
+		Context(it.GetContext()).
+		Trace(it.GetTrace()).
+		Data(it.Get()).
+		Priority(it.GetPriority())
+
+	res, err := h.inner.RunRes(in)
+	restore()
+
+	if res != nil {
+		it.Set(res)
+	}
+
+	return err
+}
+
+// AddSubConveyor adds the inner conveyor as single stage of this one.
+// The inner conveyor is started, stopped and waited together with this one.
+// Its statistic is included to ManagerData with the "name/" prefix.
+func (c *Conveyor) AddSubConveyor(name faces.Name, minCount, maxCount int, inner faces.IConveyor) error {
+	if err := c.AddHandler(name, minCount, maxCount, AsHandler(inner)); err != nil {
+		return err
+	}
+
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	c.data.subConveyors = append(c.data.subConveyors, &subConveyor{name: name, inner: inner})
+
+	return nil
+}
+
+func (c *Conveyor) subConveyorStatistic() []*nodes.ManagerData {
+	out := make([]*nodes.ManagerData, 0)
+	for _, sub := range c.data.subConveyors {
+		for _, md := range sub.inner.Statistic().ManagerData {
+			md.Name = string(sub.name) + "/" + md.Name
+			out = append(out, md)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,109 @@
+package conveyor_test
+
+import (
+	"context"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+	"github.com/iostrovok/conveyor/item"
+)
+
+type selfMessage struct {
+	item.Item
+
+	pathMessage
+}
+
+type selfPathHandler struct {
+	faces.EmptyHandler
+
+	name faces.Name
+}
+
+func newSelfPathHandler(name faces.Name) (faces.IHandler, error) {
+	return &selfPathHandler{name: name}, nil
+}
+
+// Run adds the name to path of item which is data of itself.
+func (h *selfPathHandler) Run(item faces.IItem) error {
+	item.(*selfMessage).add(h.name)
+
+	return nil
+}
+
+type failHandler struct {
+	faces.EmptyHandler
+}
+
+func newFailHandler(_ faces.Name) (faces.IHandler, error) {
+	return &failHandler{}, nil
+}
+
+func (h *failHandler) Run(item faces.IItem) error {
+	if msg, ok := item.Get().(*pathMessage); ok && msg.id%3 == 0 {
+		return errors.New("inner error")
+	}
+
+	return nil
+}
+
+func (s *testSuite) TestSubConveyor(c *C) {
+	inner := conveyor.New(5, faces.ChanStack, "inner")
+	c.Assert(inner.AddHandler("inner-1", 1, 2, newPathHandler), IsNil)
+	c.Assert(inner.AddHandler("inner-fail", 1, 2, newFailHandler), IsNil)
+	c.Assert(inner.AddHandler("inner-2", 1, 2, newPathHandler), IsNil)
+
+	outer := conveyor.New(10, faces.ChanStdGo, "outer")
+	c.Assert(outer.AddHandler("outer-1", 1, 2, newPathHandler), IsNil)
+	c.Assert(outer.AddSubConveyor("sub", 1, 2, inner), IsNil)
+	c.Assert(outer.AddHandler("outer-2", 1, 2, newPathHandler), IsNil)
+	c.Assert(outer.Start(context.Background()), IsNil)
+
+	for i := 1; i < 10; i++ {
+		res, err := outer.RunRes(input.New().Data(&pathMessage{id: i}))
+		if i%3 == 0 {
+			c.Assert(err, ErrorMatches, "inner error")
+			c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"outer-1", "inner-1"})
+		} else {
+			c.Assert(err, IsNil)
+			c.Assert(res.(*pathMessage).path, DeepEquals, []faces.Name{"outer-1", "inner-1", "inner-2", "outer-2"})
+		}
+	}
+
+	c.Assert(len(outer.Statistic().ManagerData), Equals, 6)
+	c.Assert(outer.Statistic().ManagerData[3].Name, Equals, "sub/inner-1")
+
+	outer.WaitAndStop()
+}
+
+func (s *testSuite) TestSubConveyorSelfItem(c *C) {
+	inner := conveyor.New(5, faces.ChanStdGo, "inner")
+	c.Assert(inner.AddHandler("inner-1", 1, 2, newSelfPathHandler), IsNil)
+
+	outer := conveyor.New(10, faces.ChanStdGo, "outer")
+	c.Assert(outer.AddHandler("outer-1", 1, 2, newSelfPathHandler), IsNil)
+	c.Assert(outer.AddSubConveyor("sub", 1, 2, inner), IsNil)
+	c.Assert(outer.AddHandler("outer-2", 1, 2, newSelfPathHandler), IsNil)
+	c.Assert(outer.Start(context.Background()), IsNil)
+
+	// the item is data of itself, the inner conveyor doesn't change its state
+	msg := &selfMessage{}
+	res, err := outer.RunRes(input.New().Data(msg).Priority(7))
+	c.Assert(err, IsNil)
+	c.Assert(res, Equals, msg)
+	c.Assert(msg.path, DeepEquals, []faces.Name{"outer-1", "inner-1", "outer-2"})
+	c.Assert(msg.GetID(), Equals, int64(1))
+	c.Assert(msg.GetPriority(), Equals, 7)
+
+	stages := make([]faces.Name, 0)
+	for _, st := range msg.Status().Stages {
+		stages = append(stages, st.Name)
+	}
+	c.Assert(stages[:3], DeepEquals, []faces.Name{"outer-1", "sub", "outer-2"})
+
+	outer.WaitAndStop()
+}
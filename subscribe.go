@@ -0,0 +1,107 @@
+package conveyor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+// subscription is a single subscriber to the processed items.
+type subscription struct {
+	ch     chan faces.IItem
+	filter faces.Predicate
+	policy faces.SubscribePolicy
+}
+
+// subscribers delivers the items which leave the conveyor to all subscriptions.
+type subscribers struct {
+	sync.RWMutex
+
+	list   []*subscription
+	closed bool
+}
+
+func (s *subscribers) add(sub *subscription) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed {
+		close(sub.ch)
+
+		return
+	}
+
+	s.list = append(s.list, sub)
+}
+
+// deliver sends item to subscriptions which accept it. Blocking subscription waits while ctx is not done.
+func (s *subscribers) deliver(ctx context.Context, item faces.IItem) {
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, sub := range s.list {
+		if sub.filter != nil && !sub.filter(item) {
+			continue
+		}
+
+		if sub.policy == faces.SubscribeDrop {
+			select {
+			case sub.ch <- item:
+			default:
+				item.LogTracef("subscriber has no room for item %d", item.GetID())
+			}
+
+			continue
+		}
+
+		select {
+		case sub.ch <- item:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// close closes the channels of all subscriptions, nothing is delivered after that.
+func (s *subscribers) close() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.closed = true
+	for _, sub := range s.list {
+		close(sub.ch)
+	}
+
+	s.list = nil
+}
+
+// Subscribe returns the channel which gets the items selected by filter after the final handlers.
+// Nil filter selects all items. Buffer is a size of channel, policy defines what happens when it's full.
+// Subscriber should read the channel with faces.SubscribeBlock policy, otherwise the conveyor is blocked.
+// Channel is closed by WaitAndStop when all items are processed.
+func (c *Conveyor) Subscribe(filter faces.Predicate, buffer int, policy faces.SubscribePolicy) <-chan faces.IItem {
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	sub := &subscription{
+		ch:     make(chan faces.IItem, buffer),
+		filter: filter,
+		policy: policy,
+	}
+
+	c.data.subscribers.add(sub)
+
+	return sub.ch
+}
+
+// leave is called for each item which leaves the conveyor by final handler.
+// The waiting results get the item first, subscribers may be slow.
+func (c *Conveyor) leave(item faces.IItem) {
+	c.data.results.Deliver(item)
+	c.data.subscribers.deliver(c.data.stopContext, item)
+}
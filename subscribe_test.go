@@ -0,0 +1,78 @@
+package conveyor_test
+
+import (
+	"context"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+func (s *testSuite) TestSubscribe(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "subscribe")
+	c.Assert(cv.AddHandler("first", 1, 2, newPathHandler), IsNil)
+	c.Assert(cv.AddHandler("fail", 1, 2, newFailHandler), IsNil)
+	c.Assert(cv.AddFinalHandler("final", 1, 1, newPathHandler), IsNil)
+
+	all := cv.Subscribe(nil, 0, faces.SubscribeBlock)
+	failed := cv.Subscribe(func(item faces.IItem) bool {
+		return item.GetError() != nil
+	}, 0, faces.SubscribeBlock)
+
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	count := make(chan int, 2)
+	for _, ch := range []<-chan faces.IItem{all, failed} {
+		go func(ch <-chan faces.IItem) {
+			n := 0
+			for item := range ch {
+				// items come after the final handlers
+				c.Check(item.Get().(*pathMessage).path, DeepEquals, []faces.Name{"first", "final"})
+				n++
+			}
+			count <- n
+		}(ch)
+	}
+
+	for i := 1; i <= 30; i++ {
+		cv.Run(input.New().Data(&pathMessage{id: i}))
+	}
+
+	cv.WaitAndStop()
+
+	total, errs := <-count, <-count
+	if total < errs {
+		total, errs = errs, total
+	}
+
+	c.Assert(total, Equals, 30)
+	c.Assert(errs, Equals, 10)
+
+	// subscription after stop is closed
+	_, ok := <-cv.Subscribe(nil, 1, faces.SubscribeBlock)
+	c.Assert(ok, Equals, false)
+}
+
+func (s *testSuite) TestSubscribeDrop(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "subscribe")
+	c.Assert(cv.AddHandler("first", 1, 2, newPathHandler), IsNil)
+
+	ch := cv.Subscribe(nil, 2, faces.SubscribeDrop)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	for i := 1; i <= 10; i++ {
+		cv.Run(input.New().Data(&pathMessage{id: i}))
+	}
+
+	// nobody reads the channel, conveyor is not blocked
+	cv.WaitAndStop()
+
+	n := 0
+	for range ch {
+		n++
+	}
+
+	c.Assert(n, Equals, 2)
+}
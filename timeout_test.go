@@ -0,0 +1,84 @@
+package conveyor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type hangHandler struct {
+	faces.EmptyHandler
+
+	release chan struct{}
+	items   chan faces.IItem
+}
+
+// Run hangs for items with negative data until release is closed and changes them after.
+func (h *hangHandler) Run(item faces.IItem) error {
+	if item.Get().(int) < 0 {
+		h.items <- item
+		<-h.release
+		item.Set(0)
+	}
+
+	return nil
+}
+
+func (s *testSuite) TestTimeout(c *C) {
+	release := make(chan struct{})
+	items := make(chan faces.IItem, 1)
+	newHangHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &hangHandler{release: release, items: items}, nil
+	}
+
+	cv := conveyor.New(10, faces.ChanStdGo, "timeout")
+	c.Assert(cv.AddHandler("hang", 1, 1, newHangHandler), IsNil)
+	c.Assert(cv.SetTimeout("hang", 50*time.Millisecond), IsNil)
+	c.Assert(cv.SetTimeout("unknown", time.Second), NotNil)
+	c.Assert(cv.SetTimeout("hang", -time.Second), NotNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	type result struct {
+		res interface{}
+		err error
+	}
+
+	results := make(chan result, 1)
+	go func() {
+		res, err := cv.RunRes(input.New().Data(-1))
+		results <- result{res: res, err: err}
+	}()
+
+	// item context is not cancelled by stage timeout
+	hung := <-items
+	c.Assert(hung.GetContext().Err(), IsNil)
+
+	// the timed out item is released at once, the handler call is still hung
+	var r result
+	select {
+	case r = <-results:
+	case <-time.After(time.Second):
+		c.Fatalf("timed out item is not released")
+	}
+
+	c.Assert(r.res, Equals, -1)
+	c.Assert(r.err, NotNil)
+
+	timeoutErr, ok := r.err.(*faces.ErrStageTimeout)
+	c.Assert(ok, Equals, true)
+	c.Assert(timeoutErr.Name, Equals, faces.Name("hang"))
+	c.Assert(timeoutErr.Error(), Equals, "handler 'hang' is timed out after 50ms")
+
+	// the stuck worker is replaced, the single worker manager still processes items
+	res, err := cv.RunRes(input.New().Data(1))
+	c.Assert(err, IsNil)
+	c.Assert(res, Equals, 1)
+
+	close(release)
+	cv.WaitAndStop()
+}
@@ -0,0 +1,48 @@
+package conveyor_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+func (s *testSuite) TestTryRun(c *C) {
+	busy := make(chan int64, 1)
+	release := make(chan struct{})
+	newGateHandler := func(_ faces.Name) (faces.IHandler, error) {
+		return &gateHandler{ids: busy, release: release}, nil
+	}
+
+	cv := conveyor.New(1, faces.ChanStdGo, "try-run")
+	c.Assert(cv.AddHandler("gate", 1, 1, newGateHandler), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	id, err := cv.TryRun(input.New().Data(1))
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, int64(1))
+	c.Assert(<-busy, Equals, id)
+
+	_, err = cv.TryRun(input.New().Data(2))
+	c.Assert(err, Equals, faces.ErrConveyorFull)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = cv.RunContext(ctx, input.New().Data(3))
+	c.Assert(err, Equals, context.DeadlineExceeded)
+
+	close(release)
+
+	// the items which don't get a place take no ids
+	id, err = cv.RunContext(context.Background(), input.New().Data(4))
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, int64(2))
+	c.Assert(<-busy, Equals, id)
+
+	cv.WaitAndStop()
+}
@@ -0,0 +1,188 @@
+package conveyor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+// TypedHandler processes the data of item as T and returns the data which goes further.
+type TypedHandler[T any] func(item faces.IItem, data T) (T, error)
+
+// TypedMapper processes the data of item as In and returns the data of another type which goes further.
+type TypedMapper[In, Out any] func(item faces.IItem, data In) (Out, error)
+
+// Typed is a conveyor which gets the data of type In and returns the data of type Out.
+// It has the typed methods of running only and the lifecycle methods of faces.IConveyor,
+// the untyped methods are hidden, so the handlers can't be added after Build.
+type Typed[In, Out any] struct {
+	cv faces.IConveyor
+}
+
+// TypedStage is a builder of typed conveyor, Cur is the type of data after the last added handler.
+// Each next handler gets the data of type Cur, so the compiler checks the chain of handlers.
+type TypedStage[In, Cur any] struct {
+	cv  faces.IConveyor
+	err error
+}
+
+// TypedFuture is a future of typed result, see faces.IFuture.
+type TypedFuture[Out any] struct {
+	faces.IFuture
+}
+
+// typedHandler adapts the typed function to faces.IHandler.
+type typedHandler[In, Out any] struct {
+	faces.EmptyHandler
+
+	name faces.Name
+	run  TypedMapper[In, Out]
+}
+
+// NewTyped is a constructor of typed conveyor builder, see New.
+// The handlers are added by AddTypedHandler and AddTypedMapper, the conveyor is made by Build.
+func NewTyped[In any](workBranchLength int, chanType faces.ChanType, name string) *TypedStage[In, In] {
+	return &TypedStage[In, In]{cv: New(workBranchLength, chanType, name)}
+}
+
+// AddTypedHandler adds the handler which processes the data of type T, see IConveyor.AddHandler.
+// The error of adding is returned by Build.
+func AddTypedHandler[In, T any](stage *TypedStage[In, T], name faces.Name, minCount, maxCount int,
+	handler TypedHandler[T]) *TypedStage[In, T] {
+	return AddTypedMapper(stage, name, minCount, maxCount, TypedMapper[T, T](handler))
+}
+
+// AddTypedMapper adds the handler which converts the data of type Cur to the data of type Out,
+// see IConveyor.AddHandler. The error of adding is returned by Build.
+func AddTypedMapper[In, Cur, Out any](stage *TypedStage[In, Cur], name faces.Name, minCount, maxCount int,
+	mapper TypedMapper[Cur, Out]) *TypedStage[In, Out] {
+	next := &TypedStage[In, Out]{cv: stage.cv, err: stage.err}
+	if next.err != nil {
+		return next
+	}
+
+	next.err = stage.cv.AddHandler(name, minCount, maxCount, func(name faces.Name) (faces.IHandler, error) {
+		return &typedHandler[Cur, Out]{name: name, run: mapper}, nil
+	})
+
+	return next
+}
+
+// Build returns the typed conveyor which result is the data of the last added handler
+// or the first error of adding the handlers.
+func (s *TypedStage[In, Out]) Build() (*Typed[In, Out], error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &Typed[In, Out]{cv: s.cv}, nil
+}
+
+// Run calls the typed function, the result is set to item if there is no error.
+func (h *typedHandler[In, Out]) Run(item faces.IItem) error {
+	data, err := typedData[In](h.name, item.Get())
+	if err != nil {
+		return err
+	}
+
+	out, err := h.run(item, data)
+	if err != nil {
+		return err
+	}
+
+	item.Set(out)
+
+	return nil
+}
+
+// typedData returns the data as T or faces.ErrTypeMismatch.
+func typedData[T any](name faces.Name, data interface{}) (T, error) {
+	out, ok := data.(T)
+	if !ok {
+		return out, &faces.ErrTypeMismatch{Name: name, Want: fmt.Sprintf("%T", out), Got: fmt.Sprintf("%T", data)}
+	}
+
+	return out, nil
+}
+
+// input creates the input with context and data.
+func (t *Typed[In, Out]) input(ctx context.Context, data In) faces.IInput {
+	return input.New().Context(ctx).Data(data)
+}
+
+// Start starts the conveyor, see IConveyor.Start.
+func (t *Typed[In, Out]) Start(ctx context.Context) error {
+	return t.cv.Start(ctx)
+}
+
+// Stop stops the conveyor, see IConveyor.Stop.
+func (t *Typed[In, Out]) Stop() {
+	t.cv.Stop()
+}
+
+// WaitAndStop waits while all items are processed and stops the conveyor, see IConveyor.WaitAndStop.
+func (t *Typed[In, Out]) WaitAndStop() {
+	t.cv.WaitAndStop()
+}
+
+// Shutdown stops the conveyor not longer than the context is done, see IConveyor.Shutdown.
+func (t *Typed[In, Out]) Shutdown(ctx context.Context) error {
+	return t.cv.Shutdown(ctx)
+}
+
+// State returns the lifecycle state of conveyor, see IConveyor.State.
+func (t *Typed[In, Out]) State() faces.ConveyorState {
+	return t.cv.State()
+}
+
+// Run sends the data to conveyor, see IConveyor.Run.
+func (t *Typed[In, Out]) Run(ctx context.Context, data In) error {
+	return t.cv.Run(t.input(ctx, data))
+}
+
+// TryRun sends the data to conveyor if it has a room for the item and returns the id of item, see IConveyor.TryRun.
+func (t *Typed[In, Out]) TryRun(ctx context.Context, data In) (int64, error) {
+	return t.cv.TryRun(t.input(ctx, data))
+}
+
+// RunContext sends the data to conveyor waiting for a room while context is not done, see IConveyor.RunContext.
+// The context is not used by item.
+func (t *Typed[In, Out]) RunContext(ctx context.Context, data In) (int64, error) {
+	return t.cv.RunContext(ctx, input.New().Data(data))
+}
+
+// RunRes sends the data to conveyor and returns the result, see IConveyor.RunRes.
+func (t *Typed[In, Out]) RunRes(ctx context.Context, data In) (Out, error) {
+	res, err := t.cv.RunRes(t.input(ctx, data))
+	if err != nil {
+		var out Out
+
+		return out, err
+	}
+
+	return typedData[Out](defaultFinalName, res)
+}
+
+// RunAsync sends the data to conveyor and returns the future of result, see IConveyor.RunAsync.
+func (t *Typed[In, Out]) RunAsync(ctx context.Context, data In) (*TypedFuture[Out], error) {
+	f, err := t.cv.RunAsync(t.input(ctx, data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedFuture[Out]{IFuture: f}, nil
+}
+
+// Result waits for the result and returns it as Out.
+func (f *TypedFuture[Out]) Result() (Out, error) {
+	res, err := f.IFuture.Result()
+	if err != nil {
+		var out Out
+
+		return out, err
+	}
+
+	return typedData[Out](defaultFinalName, res)
+}
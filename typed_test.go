@@ -0,0 +1,114 @@
+package conveyor_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+)
+
+func (s *testSuite) TestTyped(c *C) {
+	stage := conveyor.NewTyped[string](10, faces.ChanStdGo, "typed")
+	trimmed := conveyor.AddTypedHandler(stage, "trim", 1, 2, func(_ faces.IItem, data string) (string, error) {
+		return strings.TrimSpace(data), nil
+	})
+	counted := conveyor.AddTypedMapper(trimmed, "len", 1, 2, func(_ faces.IItem, data string) (int, error) {
+		return len(data), nil
+	})
+	doubled := conveyor.AddTypedHandler(counted, "double", 1, 2, func(_ faces.IItem, data int) (int, error) {
+		return 2 * data, nil
+	})
+
+	cv, err := doubled.Build()
+	c.Assert(err, IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	res, err := cv.RunRes(context.Background(), "  abc ")
+	c.Assert(err, IsNil)
+	c.Assert(res, Equals, 6)
+
+	f, err := cv.RunAsync(context.Background(), "abcd")
+	c.Assert(err, IsNil)
+
+	res, err = f.Result()
+	c.Assert(err, IsNil)
+	c.Assert(res, Equals, 8)
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestTypedBuildError(c *C) {
+	stage := conveyor.NewTyped[int](10, faces.ChanStdGo, "typed")
+	same := conveyor.AddTypedHandler(stage, "same", 1, 1, func(_ faces.IItem, data int) (int, error) {
+		return data, nil
+	})
+	again := conveyor.AddTypedHandler(same, "same", 1, 1, func(_ faces.IItem, data int) (int, error) {
+		return data, nil
+	})
+	text := conveyor.AddTypedMapper(again, "text", 1, 1, func(_ faces.IItem, data int) (string, error) {
+		return strconv.Itoa(data), nil
+	})
+
+	// the first error of adding is kept till Build
+	cv, err := text.Build()
+	c.Assert(err, NotNil)
+	c.Assert(cv, IsNil)
+}
+
+func (s *testSuite) TestTypedMismatch(c *C) {
+	stage := conveyor.NewTyped[int](10, faces.ChanStdGo, "typed")
+	cv, err := conveyor.AddTypedHandler(stage, "split", 1, 1, func(item faces.IItem, data int) (int, error) {
+		// the parent gets the list of children's results
+		item.Split(data, data)
+
+		return data, nil
+	}).Build()
+	c.Assert(err, IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	_, err = cv.RunRes(context.Background(), 1)
+	c.Assert(err, ErrorMatches, "handler 'final-system-handler' wants data of type int, got \\[\\]interface \\{\\}")
+
+	mismatch, ok := err.(*faces.ErrTypeMismatch)
+	c.Assert(ok, Equals, true)
+	c.Assert(mismatch.Want, Equals, "int")
+
+	cv.WaitAndStop()
+}
+
+func (s *testSuite) TestTypedTryRun(c *C) {
+	release := make(chan struct{})
+	stage := conveyor.NewTyped[int](1, faces.ChanStdGo, "typed")
+	cv, err := conveyor.AddTypedHandler(stage, "wait", 1, 1, func(_ faces.IItem, data int) (int, error) {
+		<-release
+
+		return data, nil
+	}).Build()
+	c.Assert(err, IsNil)
+	c.Assert(cv.State(), Equals, faces.ConveyorCreated)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	id, err := cv.TryRun(context.Background(), 1)
+	c.Assert(err, IsNil)
+	c.Assert(id > 0, Equals, true)
+
+	// the single place of work bench is taken
+	_, err = cv.TryRun(context.Background(), 2)
+	c.Assert(errors.Is(err, faces.ErrConveyorFull), Equals, true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = cv.RunContext(ctx, 3)
+	c.Assert(err, NotNil)
+
+	close(release)
+	cv.WaitAndStop()
+	c.Assert(cv.State(), Equals, faces.ConveyorStopped)
+}
@@ -0,0 +1,407 @@
+package conveyor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/item"
+)
+
+// windowStage keeps the open windows of stage. It's shared by all workers of manager.
+type windowStage struct {
+	sync.Mutex
+
+	conveyor *Conveyor
+	name     faces.Name
+
+	window  time.Duration
+	slide   time.Duration
+	keyFn   faces.KeyFunc
+	reducer faces.Reducer
+	policy  faces.WindowPolicy
+
+	// input channel of stage, aggregate items are sent to it
+	in faces.IChan
+
+	windows map[windowKey]*openWindow
+
+	// index of absorbed item => number of open windows with it
+	held map[int]int
+
+	// ids of aggregate items which are passed by stage without processing
+	aggregates map[int64]bool
+}
+
+type windowKey struct {
+	key   string
+	start int64
+}
+
+type openWindow struct {
+	faces.Window
+	indexes []int
+}
+
+// windowHandler adds items to windows of stage and passes the aggregate items further.
+type windowHandler struct {
+	faces.EmptyHandler
+
+	stage *windowStage
+}
+
+// AddWindowHandler adds the stage which groups items by key and time window.
+// Each window is closed after its end and the aggregate item made by reducer goes to the next handler.
+// If slide is zero or equals window the windows are tumbling, otherwise they are sliding and item may belong to several windows.
+// Policy defines what happens with the income items, see faces.WindowPolicy.
+// Pending windows are closed by WaitAndStop.
+func (c *Conveyor) AddWindowHandler(name faces.Name, minCount, maxCount int, window, slide time.Duration,
+	keyFn faces.KeyFunc, reducer faces.Reducer, policy faces.WindowPolicy) error {
+	if window <= 0 {
+		return errors.New("window of handler '" + string(name) + "' should be positive")
+	}
+
+	if slide < 0 {
+		return errors.New("slide of handler '" + string(name) + "' can not be negative")
+	}
+
+	if keyFn == nil || reducer == nil {
+		return errors.New("window handler '" + string(name) + "' needs key function and reducer")
+	}
+
+	if slide == 0 {
+		slide = window
+	}
+
+	stage := &windowStage{
+		conveyor:   c,
+		name:       name,
+		window:     window,
+		slide:      slide,
+		keyFn:      keyFn,
+		reducer:    reducer,
+		policy:     policy,
+		windows:    map[windowKey]*openWindow{},
+		held:       map[int]int{},
+		aggregates: map[int64]bool{},
+	}
+
+	giveBirth := func(_ faces.Name) (faces.IHandler, error) {
+		return &windowHandler{stage: stage}, nil
+	}
+
+	if err := c.AddHandler(name, minCount, maxCount, giveBirth); err != nil {
+		return err
+	}
+
+	c.data.Lock()
+	defer c.data.Unlock()
+
+	c.data.windows = append(c.data.windows, stage)
+
+	return nil
+}
+
+// Run passes the aggregate items and adds the rest ones to windows.
+func (h *windowHandler) Run(it faces.IItem) error {
+	if h.stage.isAggregate(it.GetID()) {
+		return nil
+	}
+
+	if h.stage.policy == faces.WindowAbsorb {
+		// item is added to windows by Held
+		it.Hold()
+
+		return nil
+	}
+
+	h.stage.add(-1, it)
+
+	// item goes to the final handlers
+	it.Stopped()
+
+	return nil
+}
+
+// Held adds the absorbed item to windows.
+func (h *windowHandler) Held(index int, it faces.IItem) {
+	h.stage.add(index, it)
+}
+
+func (s *windowStage) isAggregate(id int64) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.aggregates[id] {
+		delete(s.aggregates, id)
+
+		return true
+	}
+
+	return false
+}
+
+// add puts the data of item to all windows which contain the current time.
+// Index is a position of absorbed item in work bench or -1.
+func (s *windowStage) add(index int, it faces.IItem) {
+	now := time.Now()
+	key := s.keyFn(it)
+
+	s.Lock()
+
+	count := 0
+	first := now.Truncate(s.slide)
+	for start := first; now.Before(start.Add(s.window)); start = start.Add(-s.slide) {
+		wk := windowKey{key: key, start: start.UnixNano()}
+		w, find := s.windows[wk]
+		if !find {
+			w = &openWindow{Window: faces.Window{Key: key, Start: start, End: start.Add(s.window)}}
+			s.windows[wk] = w
+		}
+
+		w.Values = append(w.Values, it.Get())
+		if index >= 0 {
+			w.indexes = append(w.indexes, index)
+		}
+
+		count++
+	}
+
+	if index >= 0 && count > 0 {
+		s.held[index] = count
+		s.conveyor.data.windowsHeld.notify()
+
+		// the item comes to the final handlers when windows are closed, the next items don't wait for it
+		if s.conveyor.data.resequencer != nil {
+			s.conveyor.data.resequencer.pass(it.GetID())
+		}
+	}
+
+	s.Unlock()
+
+	if index >= 0 && count == 0 {
+		// item is between windows (slide is greater than window)
+		s.release(index)
+	}
+}
+
+// close closes the windows which are ended before the time or all windows if the time is zero.
+// It returns the number of closed windows. Windows are not closed after haltWindows.
+func (s *windowStage) close(now time.Time) int {
+	c := s.conveyor
+
+	// haltWindows waits for the closing, the stage channels are open until it's finished
+	c.data.windowsMu.RLock()
+	defer c.data.windowsMu.RUnlock()
+
+	if c.data.windowsCtx.Err() != nil {
+		return 0
+	}
+
+	s.Lock()
+
+	closed := make([]*openWindow, 0)
+	for wk, w := range s.windows {
+		if now.IsZero() || !now.Before(w.End) {
+			closed = append(closed, w)
+			delete(s.windows, wk)
+		}
+	}
+
+	released := make([]int, 0)
+	for _, w := range closed {
+		for _, index := range w.indexes {
+			s.held[index]--
+			if s.held[index] == 0 {
+				delete(s.held, index)
+				released = append(released, index)
+			}
+		}
+	}
+
+	s.Unlock()
+
+	// absorbed items free the places in work bench for aggregate items
+	for _, index := range released {
+		s.release(index)
+	}
+
+	for _, w := range closed {
+		s.emit(w.Window)
+	}
+
+	return len(closed)
+}
+
+// cancel drops the open windows without aggregates and returns the indexes of absorbed items which were held by them.
+func (s *windowStage) cancel() []int {
+	s.Lock()
+	defer s.Unlock()
+
+	out := make([]int, 0, len(s.held))
+	for index := range s.held {
+		out = append(out, index)
+	}
+
+	s.windows = map[windowKey]*openWindow{}
+	s.held = map[int]int{}
+
+	return out
+}
+
+// release sends the absorbed item to the final handlers.
+func (s *windowStage) release(index int) {
+	c := s.conveyor
+
+	it, err := c.data.workBench.Get(index)
+	if err != nil || it == nil {
+		return
+	}
+
+	it.PushedToChannel(defaultFinalName)
+	c.data.outCh.Push(index)
+}
+
+// emit sends the aggregate item of window to the stage input. Stage passes it to the next handler.
+func (s *windowStage) emit(w faces.Window) {
+	c := s.conveyor
+
+	it := item.New(context.Background(), nil)
+	it.SetPriority(c.data.defaultPriority)
+	it.Set(s.reducer(w))
+
+	index, err := c.data.workBench.AddContext(c.data.windowsCtx, it)
+	if err != nil {
+		// conveyor is stopped, the aggregate is dropped
+		return
+	}
+
+	c.numerate(it)
+
+	s.Lock()
+	s.aggregates[it.GetID()] = true
+	s.Unlock()
+
+	it.PushedToChannel(s.name)
+	it.Start()
+	s.in.Push(index)
+}
+
+// heldCount returns the number of items which are held by stage.
+func (s *windowStage) heldCount() int {
+	s.Lock()
+	defer s.Unlock()
+
+	return len(s.held)
+}
+
+// run closes the ended windows until context is done.
+func (s *windowStage) run(ctx context.Context) {
+	defer s.conveyor.data.windowsGroup.Done()
+
+	ticker := time.NewTicker(s.tickPeriod())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.close(now)
+		}
+	}
+}
+
+// tickPeriod returns the period of checking windows, a small part of the slide.
+func (s *windowStage) tickPeriod() time.Duration {
+	period := s.slide / 10
+	if period < time.Millisecond {
+		period = time.Millisecond
+	}
+
+	return period
+}
+
+// heldByWindows returns the number of items which are held by all window stages.
+func (c *Conveyor) heldByWindows() int {
+	out := 0
+	for _, s := range c.data.windows {
+		out += s.heldCount()
+	}
+
+	return out
+}
+
+// waitHeld waits while all items in work bench are held by windows or work bench is empty.
+// It's woken up when item leaves the work bench or is held by window.
+// It returns false if conveyor is stopped by Stop before.
+func (c *Conveyor) waitHeld() bool {
+	for {
+		// the channels are taken before checking, so the changes after checking are not missed
+		cleaned, held := c.data.workBench.Changed(), c.data.windowsHeld.wait()
+
+		if c.data.workBench.Count() <= c.heldByWindows() {
+			return true
+		}
+
+		select {
+		case <-cleaned:
+		case <-held:
+		case <-c.data.stopped:
+			return false
+		}
+	}
+}
+
+// signal wakes up the waiters when something is changed.
+type signal struct {
+	sync.Mutex
+
+	ch chan struct{}
+}
+
+// wait returns the channel which is closed by the next notify.
+func (s *signal) wait() <-chan struct{} {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.ch == nil {
+		s.ch = make(chan struct{})
+	}
+
+	return s.ch
+}
+
+// notify wakes up all waiters.
+func (s *signal) notify() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.ch != nil {
+		close(s.ch)
+		s.ch = nil
+	}
+}
+
+// haltWindows stops closing of windows by time and waits for the windows which are being closed.
+// Windows are not closed and aggregate items are not emitted since the call.
+func (c *Conveyor) haltWindows() {
+	c.data.cancelWindows()
+	c.data.windowsGroup.Wait()
+
+	c.data.windowsMu.Lock()
+	defer c.data.windowsMu.Unlock()
+}
+
+// flushWindows closes all open windows. It returns true if any window was closed.
+func (c *Conveyor) flushWindows() bool {
+	closed := 0
+	for _, s := range c.data.windows {
+		closed += s.close(time.Time{})
+	}
+
+	return closed > 0
+}
@@ -0,0 +1,144 @@
+package conveyor_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor"
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/input"
+)
+
+type rollup struct {
+	key   string
+	count int
+}
+
+type rollupCollector struct {
+	faces.EmptyHandler
+
+	sync.Mutex
+	rollups []rollup
+}
+
+func (h *rollupCollector) Run(item faces.IItem) error {
+	if r, ok := item.Get().(rollup); ok {
+		h.Lock()
+		h.rollups = append(h.rollups, r)
+		h.Unlock()
+	}
+
+	return nil
+}
+
+func (h *rollupCollector) total() map[string]int {
+	h.Lock()
+	defer h.Unlock()
+
+	out := map[string]int{}
+	for _, r := range h.rollups {
+		out[r.key] += r.count
+	}
+
+	return out
+}
+
+func customerKey(item faces.IItem) string {
+	return "customer-" + strconv.Itoa(item.Get().(int)%3)
+}
+
+func countReducer(w faces.Window) interface{} {
+	return rollup{key: w.Key, count: len(w.Values)}
+}
+
+func (s *testSuite) TestWindowHandler(c *C) {
+	for _, policy := range []faces.WindowPolicy{faces.WindowComplete, faces.WindowAbsorb} {
+		collector := &rollupCollector{}
+		newCollector := func(_ faces.Name) (faces.IHandler, error) {
+			return collector, nil
+		}
+
+		cv := conveyor.New(50, faces.ChanStdGo, "window")
+		c.Assert(cv.AddWindowHandler("window", 2, 4, time.Hour, 0, customerKey, countReducer, policy), IsNil)
+		c.Assert(cv.AddHandler("collector", 1, 1, newCollector), IsNil)
+		c.Assert(cv.Start(context.Background()), IsNil)
+
+		for i := 0; i < 30; i++ {
+			cv.Run(input.New().Data(i))
+		}
+
+		// pending windows are closed on drain
+		cv.WaitAndStop()
+
+		c.Assert(collector.total(), DeepEquals, map[string]int{"customer-0": 10, "customer-1": 10, "customer-2": 10})
+		c.Assert(cv.WorkBench().Count(), Equals, 0)
+	}
+}
+
+func (s *testSuite) TestWindowHandlerSliding(c *C) {
+	collector := &rollupCollector{}
+	newCollector := func(_ faces.Name) (faces.IHandler, error) {
+		return collector, nil
+	}
+
+	cv := conveyor.New(50, faces.ChanStdGo, "window")
+	c.Assert(cv.AddWindowHandler("window", 1, 2, 40*time.Millisecond, 20*time.Millisecond,
+		customerKey, countReducer, faces.WindowAbsorb), IsNil)
+	c.Assert(cv.AddHandler("collector", 1, 1, newCollector), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// absorbed item is finished when the last window with it is closed by ticker
+	res, err := cv.RunRes(input.New().Data(3))
+	c.Assert(err, IsNil)
+	c.Assert(res, Equals, 3)
+
+	cv.WaitAndStop()
+
+	// each item belongs to two sliding windows
+	c.Assert(collector.total(), DeepEquals, map[string]int{"customer-0": 2})
+}
+
+func (s *testSuite) TestWindowHandlerErrors(c *C) {
+	cv := conveyor.New(10, faces.ChanStdGo, "window")
+
+	c.Assert(cv.AddWindowHandler("window", 1, 1, 0, 0, customerKey, countReducer, faces.WindowComplete), NotNil)
+	c.Assert(cv.AddWindowHandler("window", 1, 1, time.Second, -1, customerKey, countReducer, faces.WindowComplete), NotNil)
+	c.Assert(cv.AddWindowHandler("window", 1, 1, time.Second, 0, nil, countReducer, faces.WindowComplete), NotNil)
+	c.Assert(cv.AddWindowHandler("window", 1, 1, time.Second, 0, customerKey, nil, faces.WindowComplete), NotNil)
+}
+
+func (s *testSuite) TestWindowHandlerStop(c *C) {
+	collector := &rollupCollector{}
+	newCollector := func(_ faces.Name) (faces.IHandler, error) {
+		return collector, nil
+	}
+
+	window := 200 * time.Millisecond
+
+	cv := conveyor.New(50, faces.ChanStdGo, "window")
+	c.Assert(cv.AddHandler("first", 1, 1, faces.MakeEmptyHandler), IsNil)
+	c.Assert(cv.AddWindowHandler("window", 1, 1, window, 0, customerKey, countReducer, faces.WindowComplete), IsNil)
+	c.Assert(cv.AddHandler("collector", 1, 1, newCollector), IsNil)
+	c.Assert(cv.Start(context.Background()), IsNil)
+
+	// items come at the beginning of window
+	now := time.Now()
+	time.Sleep(now.Truncate(window).Add(window + 5*time.Millisecond).Sub(now))
+
+	for i := 0; i < 10; i++ {
+		c.Assert(cv.Run(input.New().Data(i)), IsNil)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	// the windows are not closed after Stop, their aggregates don't come to the closed stage channels
+	cv.Stop()
+	time.Sleep(2 * window)
+
+	c.Assert(cv.State(), Equals, faces.ConveyorStopped)
+	c.Assert(collector.total(), DeepEquals, map[string]int{})
+}
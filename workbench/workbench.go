@@ -4,6 +4,7 @@ Package workbench supports the simple realization of ITrace.
 package workbench
 
 import (
+	"context"
 	"github.com/pkg/errors"
 	"sync"
 
@@ -19,6 +20,9 @@ type WorkBench struct {
 	data         []faces.IItem
 	last         int
 	activeNumber int
+
+	// it's closed when the active item is removed
+	changed chan struct{}
 }
 
 // New is a constructor.
@@ -39,23 +43,56 @@ func New(len int) faces.IWorkBench {
 
 // Add puts new IItem by number in WorkBench
 func (w *WorkBench) Add(item faces.IItem) int {
-	i := 0
-	ok := false
+	i, ok := <-w.chWait
+	if !ok {
+		return -1
+	}
+
+	w.put(i, item)
+
+	return i
+}
+
+// TryAdd puts new IItem in WorkBench if it has a free place, it doesn't wait.
+func (w *WorkBench) TryAdd(item faces.IItem) (int, bool) {
 	select {
-	case i, ok = <-w.chWait:
+	case i, ok := <-w.chWait:
 		if !ok {
-			return -1
+			return -1, false
 		}
 
-		w.Lock()
-		if w.data[i] == nil {
-			w.activeNumber++
+		w.put(i, item)
+
+		return i, true
+	default:
+		return -1, false
+	}
+}
+
+// AddContext puts new IItem in WorkBench, it waits for a free place while context is not done.
+func (w *WorkBench) AddContext(ctx context.Context, item faces.IItem) (int, error) {
+	select {
+	case i, ok := <-w.chWait:
+		if !ok {
+			return -1, errors.New("work bench is closed")
 		}
-		w.data[i] = item
-		w.Unlock()
+
+		w.put(i, item)
+
+		return i, nil
+	case <-ctx.Done():
+		return -1, ctx.Err()
 	}
+}
 
-	return i
+func (w *WorkBench) put(i int, item faces.IItem) {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.data[i] == nil {
+		w.activeNumber++
+	}
+	w.data[i] = item
 }
 
 // Get returns IItem by number in WorkBench
@@ -75,11 +112,27 @@ func (w *WorkBench) Len() int {
 	return w.last + 1
 }
 
-// Number returns the number of active IItem in WorkBench
+// Count returns the number of active IItem in WorkBench
 func (w *WorkBench) Count() int {
+	w.RLock()
+	defer w.RUnlock()
+
 	return w.activeNumber
 }
 
+// Changed returns the channel which is closed when the next active IItem is removed by Clean.
+// The channel is taken before checking the Count, so the removing after checking is not missed.
+func (w *WorkBench) Changed() <-chan struct{} {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.changed == nil {
+		w.changed = make(chan struct{})
+	}
+
+	return w.changed
+}
+
 // Clean removes IItem from WorkBench (makes no-active)
 func (w *WorkBench) Clean(i int) {
 	if w.last < i || i < 0 {
@@ -90,6 +143,11 @@ func (w *WorkBench) Clean(i int) {
 	if w.data[i] != nil {
 		w.data[i] = nil
 		w.activeNumber--
+
+		if w.changed != nil {
+			close(w.changed)
+			w.changed = nil
+		}
 	}
 	w.Unlock()
 
@@ -97,6 +155,35 @@ func (w *WorkBench) Clean(i int) {
 	w.chWait <- i
 }
 
+// Find returns active IItem by its id
+func (w *WorkBench) Find(id int64) (faces.IItem, bool) {
+	w.RLock()
+	defer w.RUnlock()
+
+	for _, item := range w.data {
+		if item != nil && item.GetID() == id {
+			return item, true
+		}
+	}
+
+	return nil, false
+}
+
+// Items returns all active IItem
+func (w *WorkBench) Items() []faces.IItem {
+	w.RLock()
+	defer w.RUnlock()
+
+	out := make([]faces.IItem, 0, w.activeNumber)
+	for _, item := range w.data {
+		if item != nil {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
 // GetPriority returns the priority for item by number. If item is not fund, return 0.
 func (w *WorkBench) GetPriority(i int) int {
 	if w.last < i || i < 0 {
@@ -7,6 +7,7 @@ import (
 	"github.com/iostrovok/conveyor/workbench"
 	"sync"
 	"testing"
+	"time"
 )
 
 const (
@@ -84,3 +85,89 @@ func (s *testSuite) TestAddAndClean(c *C) {
 	//c.Logf("TestStepByStep: success: %d, total: %d\n", success, total)
 	c.Assert(wb.Count(), Equals, 0)
 }
+
+func (s *testSuite) TestFind(c *C) {
+	wb := workbench.New(lastID)
+
+	it := item.New(context.Background(), nil)
+	it.SetID(lastID)
+	i := wb.Add(it)
+
+	found, ok := wb.Find(lastID)
+	c.Assert(ok, Equals, true)
+	c.Assert(found, Equals, it)
+
+	wb.Clean(i)
+
+	_, ok = wb.Find(lastID)
+	c.Assert(ok, Equals, false)
+}
+
+func (s *testSuite) TestTryAdd(c *C) {
+	wb := workbench.New(1)
+
+	i, ok := wb.TryAdd(item.New(context.Background(), nil))
+	c.Assert(ok, Equals, true)
+
+	_, ok = wb.TryAdd(item.New(context.Background(), nil))
+	c.Assert(ok, Equals, false)
+
+	wb.Clean(i)
+
+	_, ok = wb.TryAdd(item.New(context.Background(), nil))
+	c.Assert(ok, Equals, true)
+}
+
+func (s *testSuite) TestAddContext(c *C) {
+	wb := workbench.New(1)
+
+	i, err := wb.AddContext(context.Background(), item.New(context.Background(), nil))
+	c.Assert(err, IsNil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = wb.AddContext(ctx, item.New(context.Background(), nil))
+	c.Assert(err, Equals, context.DeadlineExceeded)
+
+	wb.Clean(i)
+
+	_, err = wb.AddContext(context.Background(), item.New(context.Background(), nil))
+	c.Assert(err, IsNil)
+}
+
+func (s *testSuite) TestItems(c *C) {
+	wb := workbench.New(lastID)
+	c.Assert(wb.Items(), HasLen, 0)
+
+	first, second := item.New(context.Background(), nil), item.New(context.Background(), nil)
+	i := wb.Add(first)
+	wb.Add(second)
+
+	wb.Clean(i)
+
+	items := wb.Items()
+	c.Assert(items, HasLen, 1)
+	c.Assert(items[0], Equals, second)
+}
+
+func (s *testSuite) TestChanged(c *C) {
+	wb := workbench.New(lastID)
+
+	i := wb.Add(item.New(context.Background(), nil))
+	changed := wb.Changed()
+
+	select {
+	case <-changed:
+		c.Fatal("channel is closed before Clean")
+	default:
+	}
+
+	wb.Clean(i)
+
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		c.Fatal("channel is not closed by Clean")
+	}
+}
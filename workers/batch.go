@@ -0,0 +1,285 @@
+package workers
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+func (w *Worker) batchMode() bool {
+	w.RLock()
+	defer w.RUnlock()
+
+	return w.batchHandler != nil
+}
+
+// collectBatch collects the indexes from input channel until batch is full or waiting time is over.
+// Stop interrupts the collecting, the collected items are processed and the worker is stopped after.
+func (w *Worker) collectBatch(ctx context.Context, first int) []int {
+	w.RLock()
+	maxBatch, maxWait := w.maxBatch, w.maxWait
+	w.RUnlock()
+
+	timer := time.NewTimer(maxWait)
+	defer timer.Stop()
+
+	batch := []int{first}
+	for len(batch) < maxBatch {
+		select {
+		case <-ctx.Done():
+			return batch
+		case <-timer.C:
+			return batch
+		case <-w.stopCh:
+			// the message is returned for the job loop
+			select {
+			case w.stopCh <- struct{}{}:
+			default:
+			}
+
+			return batch
+		case i, ok := <-w.in.ChanOut():
+			if !ok {
+				// worker is stopped on the next reading
+				return batch
+			}
+
+			batch = append(batch, i)
+		}
+	}
+
+	return batch
+}
+
+// processBatch processes the items together. Skipped items are sent further one by one.
+func (w *Worker) processBatch(ctx context.Context, indexes []int) {
+	items := make([]faces.IItem, 0, len(indexes))
+	active := make([]int, 0, len(indexes))
+	keys := make([]string, 0, len(indexes))
+
+	// the breaker of item and the probe flag are taken before the run, as process does
+	breakers := make([]faces.IBreaker, 0, len(indexes))
+	probes := make([]bool, 0, len(indexes))
+
+	for _, i := range indexes {
+		item, err := w.workBench.Get(i)
+		if err != nil {
+			w.logf("%s gets error for %d workBench.Get: %s", w.id, i, err.Error())
+
+			continue
+		}
+
+		key := w.key(item)
+		w.received(item)
+		if nextCh, nextName, skipped := w.skip(item); skipped {
+			w.push(i, item, nextCh, nextName)
+			w.done(key)
+
+			continue
+		}
+
+		breaker, probe := w.getBreaker(item), false
+		if breaker != nil {
+			var allowed bool
+			if allowed, probe = breaker.Allow(); !allowed {
+				nextCh, nextName := w.rejected(i, item, breaker.Policy())
+				w.push(i, item, nextCh, nextName)
+				w.done(key)
+
+				continue
+			}
+		}
+
+		items = append(items, item)
+		active = append(active, i)
+		keys = append(keys, key)
+		breakers = append(breakers, breaker)
+		probes = append(probes, probe)
+	}
+
+	if len(items) == 0 {
+		return
+	}
+
+	errs := w.runBatch(ctx, items)
+	for k, item := range items {
+		if breakers[k] != nil {
+			breakers[k].Done(errs[k], probes[k])
+		}
+
+		if errs[k] != nil {
+			// item doesn't go to the route of failed run
+			item.RouteTo(faces.EmptySkipName)
+		}
+
+		nextCh, nextName := w.debriefing(active[k], errs[k], item)
+		w.push(active[k], item, nextCh, nextName)
+		w.done(keys[k])
+	}
+}
+
+func doitBatch(internalErr chan []error, handler faces.IBatchHandler, items []faces.IItem) {
+	defer recoverBatch(internalErr, items)
+
+	internalErr <- handler.RunBatch(items)
+}
+
+// doitBatchWithTest calls the test method of handler, RunBatchTest + suffix or RunBatchTest, if it's defined.
+// The test object of the first item is used for the whole batch.
+func doitBatchWithTest(internalErr chan []error, handler faces.IBatchHandler, items []faces.IItem) {
+	defer recoverBatch(internalErr, items)
+
+	testObject := items[0].GetTestObject()
+	values := []reflect.Value{reflect.ValueOf(items), reflect.ValueOf(testObject.TestObject())}
+	st := reflect.TypeOf(handler)
+
+	for _, name := range []string{
+		faces.RunBatchTestHandlerPrefix + testObject.Suffix(),
+		faces.RunBatchTestHandlerPrefix,
+	} {
+		if _, ok := st.MethodByName(name); ok {
+			var errs []error
+
+			res := reflect.ValueOf(handler).MethodByName(name).Call(values)
+			if len(res) > 0 && !res[0].IsNil() {
+				errs = res[0].Interface().([]error)
+			}
+			internalErr <- errs
+
+			return
+		}
+	}
+
+	internalErr <- handler.RunBatch(items)
+}
+
+func recoverBatch(internalErr chan []error, items []faces.IItem) {
+	if e := recover(); e != nil {
+		err := errors.WithStack(&faces.ErrPanic{Value: e})
+		errs := make([]error, len(items))
+		for i := range errs {
+			errs[i] = err
+		}
+		internalErr <- errs
+	}
+}
+
+// runBatch returns the error for each item.
+// Stopped items and items with canceled context are not passed to handler.
+func (w *Worker) runBatch(ctx context.Context, items []faces.IItem) []error {
+	out := make([]error, len(items))
+
+	// single token for whole batch, it's a single call of handler
+	if err := w.waitRateLimit(ctx); err != nil {
+		for k := range out {
+			out[k] = err
+		}
+
+		return out
+	}
+
+	atomic.AddInt32(w.activeWorkers, 1)
+	defer atomic.AddInt32(w.activeWorkers, -1)
+
+	batch := make([]faces.IItem, 0, len(items))
+	positions := make([]int, 0, len(items))
+
+	for k, item := range items {
+		if item.IsStopped() && w.typ == faces.WorkerManagerType {
+			// IsStopped indicates that item should only be processed by the Final or Error Handlers
+			out[k] = item.GetCancelReason()
+
+			continue
+		}
+
+		if item.GetContext().Err() != nil {
+			out[k] = errors.New(w.id + " processing is stopped by item context")
+			if reason := item.GetCancelReason(); reason != nil {
+				out[k] = reason
+			}
+
+			continue
+		}
+
+		batch = append(batch, item)
+		positions = append(positions, k)
+	}
+
+	if len(batch) == 0 {
+		return out
+	}
+
+	w.RLock()
+	handler := w.batchHandler
+	w.RUnlock()
+
+	timer, stopTimer := w.timer()
+	defer stopTimer()
+
+	// the handler call may be given up by timeout, so handler gets the copies of items
+	work, applies := batch, make([]func(), 0, len(batch))
+	if timer != nil {
+		work = make([]faces.IItem, len(batch))
+		for k, item := range batch {
+			var apply func()
+			work[k], apply = item.Detach()
+			applies = append(applies, apply)
+		}
+	}
+
+	internalErr := make(chan []error, 1)
+	if testObject := batch[0].GetTestObject(); testObject == nil || !testObject.IsTestMode() {
+		go doitBatch(internalErr, handler, work)
+	} else {
+		go doitBatchWithTest(internalErr, handler, work)
+	}
+
+	var errs []error
+	select {
+	case <-timer:
+		err := w.timedOut(ctx, func() { <-internalErr })
+
+		errs = make([]error, len(batch))
+		for k := range errs {
+			errs[k] = err
+		}
+	case <-ctx.Done():
+		err := errors.New(w.id + " processing is stopped by global context")
+
+		// whole process is stopped. Factor doesn't work more.
+		w.globalStop = true
+
+		errs = make([]error, len(batch))
+		for k, item := range batch {
+			// stops current item on conveyor
+			item.Cancel()
+			errs[k] = err
+		}
+	case errs = <-internalErr:
+		for _, apply := range applies {
+			apply()
+		}
+
+		if errs != nil && len(errs) != len(batch) {
+			err := errors.Errorf("%s batch handler returned %d errors for %d items", w.id, len(errs), len(batch))
+
+			errs = make([]error, len(batch))
+			for k := range errs {
+				errs[k] = err
+			}
+		}
+	}
+
+	if errs != nil {
+		for k, pos := range positions {
+			out[pos] = errs[k]
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,186 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/protobuf/go/nodes"
+)
+
+const (
+	defaultBreakerWindow      = 10 * time.Second
+	defaultBreakerMinRequests = 10
+	defaultBreakerThreshold   = 0.5
+	defaultBreakerCooldown    = 5 * time.Second
+
+	// breakerBuckets is a number of parts of sliding window.
+	breakerBuckets = 10
+)
+
+// Breaker is a circuit breaker supported the faces.IBreaker interface.
+type Breaker struct {
+	sync.Mutex
+
+	config faces.BreakerConfig
+
+	state    nodes.BreakerState
+	openedAt time.Time
+	opened   uint64
+	probing  bool
+
+	buckets []breakerBucket
+}
+
+type breakerBucket struct {
+	start         time.Time
+	total, failed int
+}
+
+// NewBreaker is a constructor.
+func NewBreaker(config faces.BreakerConfig) *Breaker {
+	if config.Window <= 0 {
+		config.Window = defaultBreakerWindow
+	}
+
+	if config.MinRequests <= 0 {
+		config.MinRequests = defaultBreakerMinRequests
+	}
+
+	if config.Threshold <= 0 || config.Threshold > 1 {
+		config.Threshold = defaultBreakerThreshold
+	}
+
+	if config.Cooldown <= 0 {
+		config.Cooldown = defaultBreakerCooldown
+	}
+
+	return &Breaker{
+		config:  config,
+		state:   nodes.BreakerState_BREAKER_CLOSED,
+		buckets: make([]breakerBucket, 0, breakerBuckets),
+	}
+}
+
+// Policy is a simple getter.
+func (b *Breaker) Policy() faces.BreakerPolicy {
+	return b.config.Policy
+}
+
+// Allow returns true if handler can be called. Open breaker becomes half-open after cooldown and allows single probe,
+// the second returned value marks this call.
+func (b *Breaker) Allow() (bool, bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	switch b.state {
+	case nodes.BreakerState_BREAKER_OPEN:
+		if time.Since(b.openedAt) < b.config.Cooldown {
+			return false, false
+		}
+
+		b.state = nodes.BreakerState_BREAKER_HALF_OPEN
+		b.probing = true
+
+		return true, true
+	case nodes.BreakerState_BREAKER_HALF_OPEN:
+		if b.probing {
+			return false, false
+		}
+
+		b.probing = true
+
+		return true, true
+	}
+
+	return true, false
+}
+
+// Done counts the result of handler call. The result of probe closes or opens the breaker again.
+func (b *Breaker) Done(err error, probe bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	now := time.Now()
+
+	switch {
+	case b.state == nodes.BreakerState_BREAKER_HALF_OPEN && probe:
+		b.probing = false
+		if err != nil {
+			b.open(now)
+
+			return
+		}
+
+		b.state = nodes.BreakerState_BREAKER_CLOSED
+		b.buckets = b.buckets[:0]
+
+		return
+	case b.state != nodes.BreakerState_BREAKER_CLOSED:
+		// calls which were started before opening
+		return
+	}
+
+	b.add(now, err != nil)
+
+	total, failed := b.count(now)
+	if total >= b.config.MinRequests && float64(failed)/float64(total) >= b.config.Threshold {
+		b.open(now)
+	}
+}
+
+func (b *Breaker) open(now time.Time) {
+	b.state = nodes.BreakerState_BREAKER_OPEN
+	b.openedAt = now
+	b.opened++
+	b.buckets = b.buckets[:0]
+}
+
+// add puts the call to the current bucket and removes the buckets out of window.
+func (b *Breaker) add(now time.Time, failed bool) {
+	size := b.config.Window / breakerBuckets
+
+	for len(b.buckets) > 0 && now.Sub(b.buckets[0].start) >= b.config.Window {
+		b.buckets = b.buckets[1:]
+	}
+
+	if len(b.buckets) == 0 || now.Sub(b.buckets[len(b.buckets)-1].start) >= size {
+		b.buckets = append(b.buckets, breakerBucket{start: now})
+	}
+
+	last := &b.buckets[len(b.buckets)-1]
+	last.total++
+	if failed {
+		last.failed++
+	}
+}
+
+// count returns the number of calls and errors in the window.
+func (b *Breaker) count(now time.Time) (int, int) {
+	total, failed := 0, 0
+	for _, bucket := range b.buckets {
+		if now.Sub(bucket.start) < b.config.Window {
+			total += bucket.total
+			failed += bucket.failed
+		}
+	}
+
+	return total, failed
+}
+
+// Info returns the current state of breaker for statistic.
+func (b *Breaker) Info() *nodes.BreakerData {
+	b.Lock()
+	defer b.Unlock()
+
+	out := &nodes.BreakerData{
+		State:  b.state,
+		Opened: b.opened,
+	}
+
+	if total, failed := b.count(time.Now()); total > 0 {
+		out.ErrorRate = float32(failed) / float32(total)
+	}
+
+	return out
+}
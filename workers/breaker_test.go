@@ -0,0 +1,80 @@
+package workers_test
+
+import (
+	"time"
+
+	. "github.com/iostrovok/check"
+	"github.com/pkg/errors"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/protobuf/go/nodes"
+	"github.com/iostrovok/conveyor/workers"
+)
+
+func allow(b *workers.Breaker) bool {
+	allowed, _ := b.Allow()
+
+	return allowed
+}
+
+func (s *testSuite) TestBreaker(c *C) {
+	b := workers.NewBreaker(faces.BreakerConfig{MinRequests: 4, Threshold: 0.5, Cooldown: 20 * time.Millisecond})
+	fail := errors.New("dependency is down")
+
+	for _, err := range []error{nil, fail, nil} {
+		c.Assert(allow(b), Equals, true)
+		b.Done(err, false)
+	}
+	c.Assert(b.Info().State, Equals, nodes.BreakerState_BREAKER_CLOSED)
+
+	// 2 errors from 4 calls
+	c.Assert(allow(b), Equals, true)
+	b.Done(fail, false)
+	c.Assert(b.Info().State, Equals, nodes.BreakerState_BREAKER_OPEN)
+	c.Assert(b.Info().Opened, Equals, uint64(1))
+	c.Assert(allow(b), Equals, false)
+
+	// single probe after cooldown
+	time.Sleep(25 * time.Millisecond)
+	allowed, probe := b.Allow()
+	c.Assert(allowed, Equals, true)
+	c.Assert(probe, Equals, true)
+	c.Assert(b.Info().State, Equals, nodes.BreakerState_BREAKER_HALF_OPEN)
+	c.Assert(allow(b), Equals, false)
+
+	// failed probe opens the breaker again
+	b.Done(fail, true)
+	c.Assert(b.Info().State, Equals, nodes.BreakerState_BREAKER_OPEN)
+	c.Assert(b.Info().Opened, Equals, uint64(2))
+
+	time.Sleep(25 * time.Millisecond)
+	c.Assert(allow(b), Equals, true)
+	b.Done(nil, true)
+	c.Assert(b.Info().State, Equals, nodes.BreakerState_BREAKER_CLOSED)
+	c.Assert(allow(b), Equals, true)
+}
+
+func (s *testSuite) TestBreakerProbe(c *C) {
+	b := workers.NewBreaker(faces.BreakerConfig{MinRequests: 1, Threshold: 1, Cooldown: 20 * time.Millisecond})
+	fail := errors.New("dependency is down")
+
+	// the call in flight is started before opening
+	c.Assert(allow(b), Equals, true)
+	c.Assert(allow(b), Equals, true)
+	b.Done(fail, false)
+	c.Assert(b.Info().State, Equals, nodes.BreakerState_BREAKER_OPEN)
+
+	time.Sleep(25 * time.Millisecond)
+	allowed, probe := b.Allow()
+	c.Assert(allowed, Equals, true)
+	c.Assert(probe, Equals, true)
+
+	// the result of call in flight is not taken as the probe result
+	b.Done(nil, false)
+	c.Assert(b.Info().State, Equals, nodes.BreakerState_BREAKER_HALF_OPEN)
+	c.Assert(allow(b), Equals, false)
+
+	b.Done(fail, true)
+	c.Assert(b.Info().State, Equals, nodes.BreakerState_BREAKER_OPEN)
+	c.Assert(b.Info().Opened, Equals, uint64(2))
+}
@@ -0,0 +1,152 @@
+package workers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iostrovok/conveyor/faces"
+	"github.com/iostrovok/conveyor/queues"
+)
+
+// KeyOrder dispatches items between workers so that items with the same key are processed one by one.
+// It supports the faces.IKeyOrder interface.
+type KeyOrder struct {
+	sync.Mutex
+
+	keyFn     faces.KeyFunc
+	workBench faces.IWorkBench
+
+	// in is an input channel of manager, ready is an input channel of workers.
+	in, ready faces.IChan
+
+	// key in processing => indexes of items which are waiting for it
+	busy map[string][]int
+
+	// it's closed when all keys are released, see idleCh
+	idle chan struct{}
+}
+
+// NewKeyOrder is a constructor. If keyFn is nil IItem.GetKey is used.
+// The channel of workers has the same type as the input one, so the priority of items is kept.
+func NewKeyOrder(keyFn faces.KeyFunc, wb faces.IWorkBench, in faces.IChan) *KeyOrder {
+	if keyFn == nil {
+		keyFn = func(item faces.IItem) string {
+			return item.GetKey()
+		}
+	}
+
+	return &KeyOrder{
+		keyFn:     keyFn,
+		workBench: wb,
+		in:        in,
+		ready:     queues.New(wb, faces.ChanType(in.Info().Type)),
+		busy:      map[string][]int{},
+	}
+}
+
+// Ready returns the channel which workers read items from.
+func (k *KeyOrder) Ready() faces.IChan {
+	return k.ready
+}
+
+// Key returns the key of item.
+func (k *KeyOrder) Key(item faces.IItem) string {
+	return k.keyFn(item)
+}
+
+// Done sends the next waiting item with the key to workers or releases the key.
+func (k *KeyOrder) Done(key string) {
+	if key == "" {
+		return
+	}
+
+	k.Lock()
+
+	waiting := k.busy[key]
+	if len(waiting) == 0 {
+		delete(k.busy, key)
+		if len(k.busy) == 0 && k.idle != nil {
+			close(k.idle)
+			k.idle = nil
+		}
+		k.Unlock()
+
+		return
+	}
+
+	k.busy[key] = waiting[1:]
+	k.Unlock()
+
+	k.ready.Push(waiting[0])
+}
+
+// acquire returns true if item can be processed now, otherwise it puts item to the waiting list of key.
+func (k *KeyOrder) acquire(index int, key string) bool {
+	if key == "" {
+		return true
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	if waiting, find := k.busy[key]; find {
+		k.busy[key] = append(waiting, index)
+
+		return false
+	}
+
+	k.busy[key] = []int{}
+
+	return true
+}
+
+// idleCh returns the channel which is closed when all keys are released or nil if they are released already.
+func (k *KeyOrder) idleCh() <-chan struct{} {
+	k.Lock()
+	defer k.Unlock()
+
+	if len(k.busy) == 0 {
+		return nil
+	}
+
+	if k.idle == nil {
+		k.idle = make(chan struct{})
+	}
+
+	return k.idle
+}
+
+// Run reads the input channel of manager in order of coming.
+// Workers' channel is closed when the input one is closed and all keys are released.
+func (k *KeyOrder) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case i, ok := <-k.in.ChanOut():
+			if !ok {
+				if idle := k.idleCh(); idle != nil {
+					select {
+					case <-idle:
+					case <-ctx.Done():
+					}
+				}
+
+				k.ready.Close()
+
+				return
+			}
+
+			item, err := k.workBench.Get(i)
+			if err != nil || item == nil {
+				k.ready.Push(i)
+
+				continue
+			}
+
+			if k.acquire(i, k.keyFn(item)) {
+				k.ready.Push(i)
+			}
+		}
+	}
+}
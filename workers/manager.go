@@ -43,8 +43,29 @@ type Manager struct {
 
 	in, out faces.IChan
 	errCh   faces.IChan
+	routes  []faces.Route
+	stages  map[faces.Name]faces.IChan
+	maxHops int
 	handler faces.GiveBirth
-	stopCh  chan struct{}
+
+	maxResumes    int
+	keepItemError bool
+
+	maxBatch int
+	maxWait  time.Duration
+	splitter faces.ISplitter
+
+	keyOrder bool
+	keyFn    faces.KeyFunc
+	keys     *KeyOrder
+	limiter  *RateLimiter
+	breaker  *Breaker
+	retry    faces.RetryPolicy
+	timeout  time.Duration
+	matcher  func(item faces.IItem) bool
+	onLeave  func(item faces.IItem)
+
+	stopCh chan struct{}
 
 	ctx     context.Context
 	workers []faces.IWorker
@@ -78,6 +99,7 @@ func NewManager(name faces.Name, typ faces.ManagerType, wb faces.IWorkBench, len
 		minCount:             minC,
 		maxCount:             maxC,
 		workers:              make([]faces.IWorker, 0),
+		routes:               make([]faces.Route, 0),
 		wgLocal:              &sync.WaitGroup{},
 		stopCh:               make(chan struct{}, stopChLength),
 		metricPeriodDuration: defaultMetricPeriodInSecond,
@@ -107,12 +129,21 @@ func (m *Manager) Statistic() *nodes.ManagerData {
 
 	if m.in != nil {
 		out.ChanBefore = []*nodes.ChanData{m.in.Info()}
+
+		if m.limiter != nil {
+			// items which are waiting for rate limit are in queue still
+			out.ChanBefore[0].NumberInCh += uint32(m.limiter.Waiting())
+		}
 	}
 
 	if m.out != nil {
 		out.ChanAfter = []*nodes.ChanData{m.out.Info()}
 	}
 
+	if m.breaker != nil {
+		out.Breaker = m.breaker.Info()
+	}
+
 	return out
 }
 
@@ -179,7 +210,181 @@ func (m *Manager) setDataToWorkers() {
 
 	for _, w := range m.workers {
 		w.SetBorderCond(m.typ, m.isLast, nextManagerName)
+		w.SetRoutes(m.routes)
+		w.SetStages(m.stages, m.maxHops)
+		w.SetMaxResumes(m.maxResumes)
+		w.SetKeepItemError(m.keepItemError)
+		w.SetBatch(m.maxBatch, m.maxWait)
+		w.SetSplitter(m.splitter)
+		w.SetRateLimiter(m.rateLimiter())
+		w.SetBreaker(m.getBreaker())
+		w.SetRetry(m.retry)
+		w.SetTimeout(m.timeout, m.replaceWorker)
+		w.SetMatcher(m.matcher)
+		w.SetOnLeave(m.onLeave)
+	}
+}
+
+// getBreaker returns the faces.IBreaker, untyped nil if the breaker is not set up.
+func (m *Manager) getBreaker() faces.IBreaker {
+	if m.breaker == nil {
+		return nil
+	}
+
+	return m.breaker
+}
+
+// rateLimiter returns the faces.IRateLimiter, untyped nil if the limit is not set up.
+func (m *Manager) rateLimiter() faces.IRateLimiter {
+	if m.limiter == nil {
+		return nil
+	}
+
+	return m.limiter
+}
+
+// SetStages is a setter. It sets up the input channels of all worker managers for IItem.RouteTo.
+func (m *Manager) SetStages(stages map[faces.Name]faces.IChan, maxHops int) faces.IManager {
+	m.Lock()
+	m.stages = stages
+	m.maxHops = maxHops
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetMaxResumes is a setter. It sets up the max number of IItem.Resume calls for single item.
+func (m *Manager) SetMaxResumes(maxResumes int) faces.IManager {
+	m.Lock()
+	m.maxResumes = maxResumes
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetKeepItemError is a setter. Errors of error handler are kept by IItem.AddHandlingError if keep is true.
+func (m *Manager) SetKeepItemError(keep bool) faces.IManager {
+	m.Lock()
+	m.keepItemError = keep
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetBatch is a setter. It sets up the max size of batch and the max time of collecting it.
+// It makes sense if handler supports the faces.IBatchHandler interface.
+func (m *Manager) SetBatch(maxBatch int, maxWait time.Duration) faces.IManager {
+	m.Lock()
+	m.maxBatch = maxBatch
+	m.maxWait = maxWait
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetSplitter is a setter. It sets up the creator of child items, see faces.IItem.Split.
+func (m *Manager) SetSplitter(splitter faces.ISplitter) faces.IManager {
+	m.Lock()
+	m.splitter = splitter
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetKeyOrder turns on the processing of items with the same key one by one and in order of coming.
+// If keyFn is nil the key of item is used, see faces.IItem.GetKey.
+func (m *Manager) SetKeyOrder(keyFn faces.KeyFunc) faces.IManager {
+	m.Lock()
+	defer m.Unlock()
+
+	m.keyOrder = true
+	m.keyFn = keyFn
+
+	return m
+}
+
+// SetRateLimit sets up the max number of handler calls per second and burst, they are shared by all workers.
+// Zero perSecond turns off the limit.
+func (m *Manager) SetRateLimit(perSecond float64, burst int) faces.IManager {
+	m.Lock()
+	if perSecond > 0 {
+		m.limiter = NewRateLimiter(perSecond, burst)
+	} else {
+		m.limiter = nil
 	}
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetRetry sets up the policy of retrying the failed items at the same stage.
+// It isn't used by batch handlers.
+func (m *Manager) SetRetry(policy faces.RetryPolicy) faces.IManager {
+	m.Lock()
+	m.retry = policy
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetTimeout sets up the max time of single handler call. The item is failed with faces.ErrStageTimeout
+// and the stuck worker is replaced by new one. Zero timeout means no limit.
+func (m *Manager) SetTimeout(timeout time.Duration) faces.IManager {
+	m.Lock()
+	m.timeout = timeout
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetMatcher sets up the selection of items which are processed by handler, other items go further as is.
+// Nil matcher selects all items.
+func (m *Manager) SetMatcher(matcher func(item faces.IItem) bool) faces.IManager {
+	m.Lock()
+	m.matcher = matcher
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetOnLeave sets up the function which is called for each item which leaves the conveyor by final handler.
+func (m *Manager) SetOnLeave(onLeave func(item faces.IItem)) faces.IManager {
+	m.Lock()
+	m.onLeave = onLeave
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
+// SetBreaker sets up the circuit breaker which is shared by all workers.
+func (m *Manager) SetBreaker(config faces.BreakerConfig) faces.IManager {
+	m.Lock()
+	m.breaker = NewBreaker(config)
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
 }
 
 // SetIsLast is a setter. It set up isLast flag to manager and all it's workers.
@@ -234,6 +439,22 @@ func (m *Manager) SetChanErr(errCh faces.IChan) faces.IManager {
 	return m
 }
 
+// GetChanIn is a simple getter. It returns the input channel or nil.
+func (m *Manager) GetChanIn() faces.IChan {
+	return m.in
+}
+
+// AddRoute adds the conditional way to another manager.
+func (m *Manager) AddRoute(route faces.Route) faces.IManager {
+	m.Lock()
+	m.routes = append(m.routes, route)
+	m.Unlock()
+
+	m.setDataToWorkers()
+
+	return m
+}
+
 // Stop stops all workers.
 func (m *Manager) Stop() {
 	m.Lock()
@@ -289,6 +510,13 @@ func (m *Manager) waitingGlobalStopAndClose() {
 
 	m.logf("[%s] all workers stopped", m.name)
 
+	if m.typ == faces.WorkerManagerType {
+		// routes are closed by worker manager whether it's last or not
+		for _, route := range m.routes {
+			route.Ch.Close()
+		}
+	}
+
 	if m.typ == faces.WorkerManagerType && !m.isLast {
 		// it's not last manages - need to close next one
 		if m.out != nil {
@@ -325,6 +553,11 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	m.ctx = ctx
 
+	if m.keyOrder && m.keys == nil {
+		m.keys = NewKeyOrder(m.keyFn, m.workBench, m.in)
+		go m.keys.Run(ctx)
+	}
+
 	if len(m.workers) >= m.minCount {
 		return nil
 	}
@@ -387,6 +620,34 @@ func (m *Manager) addOneWorker() error {
 		return nil
 	}
 
+	return m.startWorker()
+}
+
+// replaceWorker starts new worker instead of the stuck one which handler is timed out.
+// New worker is started even if in channel is closed, it may have items yet.
+func (m *Manager) replaceWorker(stuck faces.IWorker) {
+	if m.checkRun(false) {
+		return
+	}
+
+	m.Lock()
+	for i, w := range m.workers {
+		if w == stuck {
+			m.workers = append(m.workers[:i], m.workers[i+1:]...)
+
+			break
+		}
+	}
+	m.Unlock()
+
+	m.logf("replaceWorker: %s", stuck.ID())
+
+	if err := m.startWorker(); err != nil {
+		m.logf("[%s] error: %s", m.name, err.Error())
+	}
+}
+
+func (m *Manager) startWorker() error {
 	m.Lock()
 	defer m.Unlock()
 
@@ -395,11 +656,20 @@ func (m *Manager) addOneWorker() error {
 
 	//m.logf("addOneWorker: %s", workerName)
 
-	w, err := NewWorker(workerName, m.name, m.workBench, m.in, m.out, m.errCh, m.handler, m.wgLocal, m.tracer, m.activeWorkers)
+	in := m.in
+	if m.keys != nil {
+		in = m.keys.Ready()
+	}
+
+	w, err := NewWorker(workerName, m.name, m.workBench, in, m.out, m.errCh, m.handler, m.wgLocal, m.tracer, m.activeWorkers)
 	if err != nil {
 		return err
 	}
 
+	if m.keys != nil {
+		w.SetKeyOrder(m.keys)
+	}
+
 	// if it's test session
 	w.SetTestMode(m.testObject)
 
@@ -409,6 +679,18 @@ func (m *Manager) addOneWorker() error {
 	}
 
 	w.SetBorderCond(m.typ, m.isLast, nextManagerName)
+	w.SetRoutes(m.routes)
+	w.SetStages(m.stages, m.maxHops)
+	w.SetMaxResumes(m.maxResumes)
+	w.SetKeepItemError(m.keepItemError)
+	w.SetBatch(m.maxBatch, m.maxWait)
+	w.SetSplitter(m.splitter)
+	w.SetRateLimiter(m.rateLimiter())
+	w.SetBreaker(m.getBreaker())
+	w.SetRetry(m.retry)
+	w.SetTimeout(m.timeout, m.replaceWorker)
+	w.SetMatcher(m.matcher)
+	w.SetOnLeave(m.onLeave)
 	m.workers = append(m.workers, w)
 
 	return w.Start(m.ctx)
@@ -0,0 +1,90 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RateLimiter is a token bucket supported the faces.IRateLimiter interface.
+type RateLimiter struct {
+	sync.Mutex
+
+	perSecond float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+
+	// number of workers which are waiting for token
+	waiting *int32
+}
+
+// NewRateLimiter is a constructor. The bucket is full at the start.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		perSecond: perSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		last:      time.Now(),
+		waiting:   new(int32),
+	}
+}
+
+// take takes the token if it's possible, otherwise it returns the time to wait for the next one.
+func (r *RateLimiter) take() (bool, time.Duration) {
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.perSecond
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+	r.last = now
+
+	if r.tokens >= 1 {
+		r.tokens--
+
+		return true, 0
+	}
+
+	return false, time.Duration((1 - r.tokens) / r.perSecond * float64(time.Second))
+}
+
+// Wait blocks until the token is got or context is done.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	ok, delay := r.take()
+	if ok {
+		return nil
+	}
+
+	atomic.AddInt32(r.waiting, 1)
+	defer atomic.AddInt32(r.waiting, -1)
+
+	for !ok {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return errors.New("waiting for rate limit is stopped by context")
+		case <-timer.C:
+		}
+
+		ok, delay = r.take()
+	}
+
+	return nil
+}
+
+// Waiting returns the number of workers which are waiting for token.
+func (r *RateLimiter) Waiting() int {
+	return int(atomic.LoadInt32(r.waiting))
+}
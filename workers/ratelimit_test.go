@@ -0,0 +1,32 @@
+package workers_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/iostrovok/check"
+
+	"github.com/iostrovok/conveyor/workers"
+)
+
+func (s *testSuite) TestRateLimiter(c *C) {
+	limiter := workers.NewRateLimiter(100, 5)
+
+	// burst is available at once
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		c.Assert(limiter.Wait(context.Background()), IsNil)
+	}
+	c.Assert(time.Since(start) < 10*time.Millisecond, Equals, true)
+
+	// the next tokens come every 10 milliseconds
+	for i := 0; i < 10; i++ {
+		c.Assert(limiter.Wait(context.Background()), IsNil)
+	}
+	c.Assert(time.Since(start) >= 90*time.Millisecond, Equals, true)
+	c.Assert(limiter.Waiting(), Equals, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Assert(limiter.Wait(ctx), NotNil)
+}
@@ -0,0 +1,60 @@
+package workers
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/iostrovok/conveyor/faces"
+)
+
+// canRetry checks that item may be processed again after the attempt.
+func canRetry(policy faces.RetryPolicy, attempt int, err error) bool {
+	if err == nil || attempt >= policy.MaxAttempts {
+		return false
+	}
+
+	return policy.Retryable == nil || policy.Retryable(err)
+}
+
+// retryDelay returns the exponential delay with jitter before the next attempt.
+func retryDelay(policy faces.RetryPolicy, attempt int) time.Duration {
+	delay := policy.Backoff
+	for i := 1; i < attempt && (policy.MaxBackoff <= 0 || delay < policy.MaxBackoff); i++ {
+		delay *= 2
+	}
+
+	if policy.MaxBackoff > 0 && delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+
+	if policy.Jitter > 0 && delay > 0 {
+		jitter := policy.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// sleep waits for delay. It returns false if global or item context is done.
+func sleep(ctx context.Context, item faces.IItem, delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-item.GetContext().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -40,6 +40,26 @@ type Worker struct {
 	name    faces.Name
 	in, out faces.IChan
 	errCh   faces.IChan
+	routes  []faces.Route
+	stages  map[faces.Name]faces.IChan
+	maxHops int
+
+	maxResumes    int
+	keepItemError bool
+
+	maxBatch     int
+	maxWait      time.Duration
+	batchHandler faces.IBatchHandler
+	splitter     faces.ISplitter
+	keys         faces.IKeyOrder
+	limiter      faces.IRateLimiter
+	breaker      faces.IBreaker
+	retry        faces.RetryPolicy
+	timeout      time.Duration
+	matcher      func(item faces.IItem) bool
+	onLeave      func(item faces.IItem)
+	replace      func(worker faces.IWorker)
+	stuck        bool
 
 	typ             faces.ManagerType
 	nextManagerName faces.Name
@@ -90,6 +110,236 @@ func (w *Worker) SetBorderCond(typ faces.ManagerType, isLast bool, nextManagerNa
 	w.typ = typ
 }
 
+// SetRoutes is a setter. It sets up the conditional ways to other managers.
+func (w *Worker) SetRoutes(routes []faces.Route) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.routes = routes
+}
+
+// SetStages is a setter. It sets up the input channels of all worker managers for IItem.RouteTo.
+func (w *Worker) SetStages(stages map[faces.Name]faces.IChan, maxHops int) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.stages = stages
+	w.maxHops = maxHops
+}
+
+// SetMaxResumes is a setter. It sets up the max number of IItem.Resume calls for single item.
+func (w *Worker) SetMaxResumes(maxResumes int) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.maxResumes = maxResumes
+}
+
+// SetKeepItemError is a setter. Errors of error handler don't replace the error of item if keep is true,
+// they are kept by IItem.AddHandlingError.
+func (w *Worker) SetKeepItemError(keep bool) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.keepItemError = keep
+}
+
+// SetBatch is a setter. It turns on the batch mode if maxBatch is positive and handler supports
+// the faces.IBatchHandler interface, the batch handler gets all items by RunBatch even if maxBatch is 1.
+func (w *Worker) SetBatch(maxBatch int, maxWait time.Duration) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.maxBatch = maxBatch
+	w.maxWait = maxWait
+	w.batchHandler = nil
+
+	if h, ok := w.handler.(faces.IBatchHandler); ok && maxBatch > 0 {
+		w.batchHandler = h
+	}
+}
+
+// SetSplitter is a setter. It sets up the creator of child items, see faces.IItem.Split.
+func (w *Worker) SetSplitter(splitter faces.ISplitter) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.splitter = splitter
+}
+
+// SetRateLimiter is a setter. Worker waits for limiter before each handler call.
+func (w *Worker) SetRateLimiter(limiter faces.IRateLimiter) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.limiter = limiter
+}
+
+// waitRateLimit waits for permission to call the handler. The waiting worker is not active.
+func (w *Worker) waitRateLimit(ctx context.Context) error {
+	w.RLock()
+	limiter := w.limiter
+	w.RUnlock()
+
+	if limiter == nil {
+		return nil
+	}
+
+	if err := limiter.Wait(ctx); err != nil {
+		return errors.Wrap(err, w.id)
+	}
+
+	return nil
+}
+
+// SetRetry is a setter. Worker calls handler again for failed item according to the policy.
+func (w *Worker) SetRetry(policy faces.RetryPolicy) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.retry = policy
+}
+
+// SetTimeout is a setter. Worker calls replace to start new worker instead of itself after timeout,
+// the timed out item goes further at once and the stuck handler is stopped when its call is over.
+func (w *Worker) SetTimeout(timeout time.Duration, replace func(worker faces.IWorker)) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.timeout = timeout
+	w.replace = replace
+}
+
+// timer returns the channel of stage timeout, nil channel if timeout is not set up.
+func (w *Worker) timer() (<-chan time.Time, func() bool) {
+	w.RLock()
+	timeout := w.timeout
+	w.RUnlock()
+
+	if timeout <= 0 {
+		return nil, func() bool { return false }
+	}
+
+	t := time.NewTimer(timeout)
+
+	return t.C, t.Stop
+}
+
+// timedOut marks worker as stuck, starts new worker instead of itself and returns the timeout error.
+// The item goes further at once, the hung handler has the copy of item (see faces.IItem.Detach)
+// and doesn't change the item. The handler is stopped when its call is over.
+func (w *Worker) timedOut(ctx context.Context, wait func()) error {
+	w.Lock()
+	w.stuck = true
+	timeout, replace := w.timeout, w.replace
+	w.Unlock()
+
+	// new worker is added before this one is done, manager should not see zero workers
+	replace(w)
+
+	go func() {
+		wait()
+		w.stopHandler(ctx)
+	}()
+
+	return &faces.ErrStageTimeout{Name: w.name, Timeout: timeout}
+}
+
+// isStuck returns true if handler of worker is timed out.
+func (w *Worker) isStuck() bool {
+	w.RLock()
+	defer w.RUnlock()
+
+	return w.stuck
+}
+
+// SetMatcher is a setter. Worker processes only matched items, other ones go further as is.
+func (w *Worker) SetMatcher(matcher func(item faces.IItem) bool) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.matcher = matcher
+}
+
+// matched checks that item should be processed by handler, see SetMatcher.
+func (w *Worker) matched(item faces.IItem) bool {
+	w.RLock()
+	matcher := w.matcher
+	w.RUnlock()
+
+	return matcher == nil || matcher(item)
+}
+
+// SetOnLeave is a setter. The function is called for each item which leaves the conveyor by final handler.
+func (w *Worker) SetOnLeave(onLeave func(item faces.IItem)) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.onLeave = onLeave
+}
+
+// SetBreaker is a setter. The circuit breaker is checked before each handler call.
+func (w *Worker) SetBreaker(breaker faces.IBreaker) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.breaker = breaker
+}
+
+// getBreaker returns the circuit breaker if item should be checked by it.
+func (w *Worker) getBreaker(item faces.IItem) faces.IBreaker {
+	if w.typ != faces.WorkerManagerType || item.IsStopped() {
+		return nil
+	}
+
+	w.RLock()
+	defer w.RUnlock()
+
+	return w.breaker
+}
+
+// rejected returns the next channel for item which is not processed because the circuit breaker is open.
+func (w *Worker) rejected(index int, item faces.IItem, policy faces.BreakerPolicy) (faces.IChan, faces.Name) {
+	if policy == faces.BreakerBypass {
+		item.LogTraceFinishTimef("[%s] is bypassed by circuit breaker", w.name)
+
+		return w.checkDebriefingOfFlight(nil, item)
+	}
+
+	return w.debriefing(index, &faces.ErrCircuitOpen{Name: w.name}, item)
+}
+
+// SetKeyOrder is a setter. Worker reports to keys when item is processed, see faces.IKeyOrder.
+func (w *Worker) SetKeyOrder(keys faces.IKeyOrder) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.keys = keys
+}
+
+// key returns the key of item if key order is used.
+func (w *Worker) key(item faces.IItem) string {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.keys == nil {
+		return ""
+	}
+
+	return w.keys.Key(item)
+}
+
+// done releases the key for the next item.
+func (w *Worker) done(key string) {
+	w.RLock()
+	keys := w.keys
+	w.RUnlock()
+
+	if keys != nil {
+		keys.Done(key)
+	}
+}
+
 // SetTestMode is a simple setter. It attaches the testObject.
 func (w *Worker) SetTestMode(testObject faces.ITestObject) {
 	w.Lock()
@@ -124,7 +374,11 @@ func (w *Worker) ID() string {
 
 // Stop stops the worker.
 func (w *Worker) Stop() {
-	if w.isStarted {
+	w.RLock()
+	isStarted := w.isStarted
+	w.RUnlock()
+
+	if isStarted {
 		w.stopCh <- struct{}{}
 	}
 }
@@ -214,14 +468,21 @@ func (w *Worker) job(ctx context.Context) {
 	go func(ticker *time.Ticker) {
 		defer func() {
 			ticker.Stop()
-			w.stopHandler(ctx)
+			if !w.isStuck() {
+				// the handler of stuck worker is stopped by timedOut
+				w.stopHandler(ctx)
+			}
+			w.Lock()
 			w.isStarted = false
+			w.Unlock()
 			w.wg.Done()
 		}()
 
+		w.Lock()
 		w.isStarted = true
+		w.Unlock()
 		for {
-			if w.globalStop {
+			if w.globalStop || w.isStuck() {
 				return
 			}
 
@@ -244,21 +505,19 @@ func (w *Worker) job(ctx context.Context) {
 					return
 				}
 
+				if w.batchMode() {
+					w.processBatch(ctx, w.collectBatch(ctx, i))
+
+					continue
+				}
+
 				if item, err := w.workBench.Get(i); err == nil {
-					item.ReceivedFromChannel()
-					item.BeforeProcess(w.name)
-					item.LogTraceFinishTimef("[%s] time in chan", w.name)
+					// key is taken before processing, handler may change the item
+					key := w.key(item)
+					w.received(item)
 					nextCh, nextName := w.process(ctx, i, item)
-					if nextName != "" {
-						item.PushedToChannel(nextName)
-					}
-
-					// send to next manager or return index to workBench
-					if nextCh != nil {
-						nextCh.Push(i)
-					} else {
-						w.workBench.Clean(i)
-					}
+					w.push(i, item, nextCh, nextName)
+					w.done(key)
 				} else {
 					w.logf("%s gets error for %d workBench.Get: %s", w.id, i, err.Error())
 				}
@@ -267,7 +526,184 @@ func (w *Worker) job(ctx context.Context) {
 	}(time.NewTicker(dur))
 }
 
+func (w *Worker) received(item faces.IItem) {
+	// the route is set up by the run of this handler only, see IItem.RouteTo
+	item.RouteTo(faces.EmptySkipName)
+	item.ReceivedFromChannel()
+	item.BeforeProcess(w.name)
+	item.LogTraceFinishTimef("[%s] time in chan", w.name)
+}
+
+// push sends item to next manager or returns index to workBench.
+func (w *Worker) push(index int, item faces.IItem, nextCh faces.IChan, nextName faces.Name) {
+	if nextName == faces.HoldName {
+		// the item is waiting in work bench, stage which holds it sends it further
+		return
+	}
+
+	if nextName != "" {
+		item.PushedToChannel(nextName)
+	}
+
+	if nextCh != nil {
+		nextCh.Push(index)
+
+		return
+	}
+
+	w.RLock()
+	onLeave := w.onLeave
+	w.RUnlock()
+
+	if onLeave != nil && w.typ == faces.FinalManagerType {
+		onLeave(item)
+	}
+
+	w.workBench.Clean(index)
+
+	if item.GetParent() != nil {
+		w.RLock()
+		splitter := w.splitter
+		w.RUnlock()
+
+		if splitter != nil {
+			splitter.Done(item)
+		}
+	}
+}
+
 func (w *Worker) process(ctx context.Context, index int, item faces.IItem) (faces.IChan, faces.Name) {
+	if nextCh, nextName, skipped := w.skip(item); skipped {
+		return nextCh, nextName
+	}
+
+	if !w.matched(item) {
+		item.LogTraceFinishTimef("[%s] is not matched", w.name)
+
+		return w.checkDebriefingOfFlight(item.GetError(), item)
+	}
+
+	w.RLock()
+	policy := w.retry
+	w.RUnlock()
+
+	breaker := w.getBreaker(item)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		probe := false
+		if breaker != nil {
+			var allowed bool
+			if allowed, probe = breaker.Allow(); !allowed {
+				return w.rejected(index, item, breaker.Policy())
+			}
+		}
+
+		if w.typ == faces.WorkerManagerType {
+			item.SetAttempts(attempt)
+		}
+
+		// main action
+		err = w.run(ctx, item)
+		if err != nil {
+			// item doesn't go to the route of failed run
+			item.RouteTo(faces.EmptySkipName)
+		}
+
+		if breaker != nil {
+			breaker.Done(err, probe)
+		}
+
+		if w.typ != faces.WorkerManagerType || w.isStuck() || !canRetry(policy, attempt, err) {
+			break
+		}
+
+		item.LogTraceFinishTimef("[%s] attempt %d has an error: %s", w.name, attempt, err.Error())
+
+		if !sleep(ctx, item, retryDelay(policy, attempt)) {
+			break
+		}
+	}
+
+	return w.debriefing(index, err, item)
+}
+
+// debriefing fixes the result of processing and returns the next channel for item.
+func (w *Worker) debriefing(index int, err error, item faces.IItem) (faces.IChan, faces.Name) {
+	w.RLock()
+	keepItemError := w.keepItemError
+	w.RUnlock()
+
+	if err != nil && w.typ == faces.ErrorManagerType && keepItemError {
+		// the next error handlers and their matchers see the original error of item
+		item.LogTraceFinishTimef("[%s] has an error", w.name)
+		item.AddHandlingError(err)
+	} else {
+		logError(w.name, err, item)
+	}
+	item.AfterProcess(w.name, err)
+
+	if err == nil && w.typ == faces.WorkerManagerType {
+		if data := item.TakeSplit(); len(data) > 0 {
+			return w.split(index, item, data)
+		}
+
+		if item.TakeHold() {
+			return w.hold(index, item)
+		}
+	}
+
+	return w.checkDebriefingOfFlight(err, item)
+}
+
+// split sends the child items to next manager, the parent is waiting for them.
+func (w *Worker) split(index int, parent faces.IItem, data []interface{}) (faces.IChan, faces.Name) {
+	w.RLock()
+	splitter := w.splitter
+	w.RUnlock()
+
+	indexes, err := splitter.Split(index, parent, data)
+	if err != nil {
+		logError(w.name, err, parent)
+
+		return w.errCh, faces.ErrorName
+	}
+
+	for _, i := range indexes {
+		child, err := w.workBench.Get(i)
+		if err != nil {
+			w.logf("%s gets error for %d workBench.Get: %s", w.id, i, err.Error())
+
+			continue
+		}
+
+		nextCh, nextName := w.checkDebriefingOfFlight(nil, child)
+		w.push(i, child, nextCh, nextName)
+	}
+
+	return nil, faces.HoldName
+}
+
+// hold passes the item to handler which keeps it in work bench.
+// Item goes further as usual if handler doesn't support the faces.IHolder interface.
+func (w *Worker) hold(index int, item faces.IItem) (faces.IChan, faces.Name) {
+	w.RLock()
+	holder, ok := w.handler.(faces.IHolder)
+	w.RUnlock()
+
+	if !ok {
+		w.logf("%s: handler does not support IHolder, item %d is not held", w.id, item.GetID())
+
+		return w.checkDebriefingOfFlight(nil, item)
+	}
+
+	holder.Held(index, item)
+
+	return nil, faces.HoldName
+}
+
+// skip checks that item should not be processed by handler and returns the next channel for it.
+func (w *Worker) skip(item faces.IItem) (faces.IChan, faces.Name, bool) {
 	// set up which handler was last.
 	item.SetLastHandler(w.name)
 
@@ -280,42 +716,40 @@ func (w *Worker) process(ctx context.Context, index int, item faces.IItem) (face
 
 		if !w.isLast && w.typ != faces.FinalManagerType {
 			//item.PushedToChannel(faces.ErrorName)
-			return w.errCh, faces.ErrorName
+			return w.errCh, faces.ErrorName, true
 		}
 
 		//pushToNotNilChan(w.errCh, index)
 
-		return w.errCh, ""
+		return w.errCh, "", true
 	}
 
 	if find {
 		item.AfterProcess(w.name, err)
 
+		if ch, name, ok := w.findRoute(item); ok {
+			return ch, name, true
+		}
+
 		// needed handler is not found
 		if !w.isLast && w.typ != faces.FinalManagerType {
 			//item.PushedToChannel(w.nextManagerName)
 
-			return w.out, w.nextManagerName
+			return w.out, w.nextManagerName, true
 		}
 
 		//pushToNotNilChan(w.out, index)
 
-		return w.out, ""
+		return w.out, "", true
 	}
 
-	// main action
-	err = w.run(ctx, item)
-	logError(w.name, err, item)
-	item.AfterProcess(w.name, err)
-	return w.checkDebriefingOfFlight(err)
-
-	//w.debriefingOfFlight(err, item)
+	return nil, "", false
 }
 
 func doit(internalErr chan error, handler faces.IHandler, item faces.IItem) {
 	defer func() {
 		if e := recover(); e != nil {
-			internalErr <- errors.Errorf("%+v", e)
+			internalErr <- errors.WithStack(&faces.ErrPanic{Value: e})
 		}
 	}()
 
@@ -325,7 +759,7 @@ func doit(internalErr chan error, handler faces.IHandler, item faces.IItem) {
 func doitWithTest(internalErr chan error, handler faces.IHandler, item faces.IItem) {
 	defer func() {
 		if e := recover(); e != nil {
-			internalErr <- errors.Errorf("%+v", e)
+			internalErr <- errors.WithStack(&faces.ErrPanic{Value: e})
 		}
 	}()
 
@@ -360,24 +794,50 @@ func doitWithTest(internalErr chan error, handler faces.IHandler, item faces.IIt
 }
 
 func (w *Worker) run(ctx context.Context, item faces.IItem) error {
+	stopped := item.IsStopped() && w.typ == faces.WorkerManagerType
+	if !stopped {
+		if err := w.waitRateLimit(item.GetContext()); err != nil {
+			return err
+		}
+	}
+
 	atomic.AddInt32(w.activeWorkers, 1)
 	defer atomic.AddInt32(w.activeWorkers, -1)
 
 	internalErr := make(chan error, 1)
 
-	if item.IsStopped() && w.typ == faces.WorkerManagerType {
+	timer, stopTimer := w.timer()
+	defer stopTimer()
+
+	// the handler call may be given up by timeout, so handler gets the copy of item
+	work, apply := item, func() {}
+	if timer != nil && !stopped {
+		work, apply = item.Detach()
+	}
+
+	if stopped {
 		// IsStopped indicates that item should only be processed by the Final or Error Handlers
-		internalErr <- nil
+		// canceled item goes to the Error Handlers with the reason
+		internalErr <- item.GetCancelReason()
 	} else {
 		if item.GetTestObject() == nil || !item.GetTestObject().IsTestMode() {
-			go doit(internalErr, w.handler, item)
+			go doit(internalErr, w.handler, work)
 		} else {
-			go doitWithTest(internalErr, w.handler, item)
+			go doitWithTest(internalErr, w.handler, work)
 		}
 	}
 
+	// error and final handlers process the item canceled by SetCancelReason till the end
+	itemDone := item.GetContext().Done()
+	if w.typ != faces.WorkerManagerType && item.GetCancelReason() != nil {
+		itemDone = nil
+	}
+
 	var err error
 	select {
+	case <-timer:
+		// item context is not cancelled, the next stages may use it
+		err = w.timedOut(ctx, func() { <-internalErr })
 	case <-ctx.Done():
 		err = errors.New(w.id + " processing is stopped by global context")
 
@@ -386,10 +846,14 @@ func (w *Worker) run(ctx context.Context, item faces.IItem) error {
 
 		// stops current item on conveyor
 		item.Cancel()
-	case <-item.GetContext().Done():
+	case <-itemDone:
 		// it's not obviously, customer handler should check it.
 		err = errors.New(w.id + " processing is stopped by item context")
-	case err = <-internalErr: /* does nothing */
+		if reason := item.GetCancelReason(); reason != nil {
+			err = reason
+		}
+	case err = <-internalErr:
+		apply()
 	}
 
 	return err
@@ -408,16 +872,106 @@ func logError(name faces.Name, err error, item faces.IItem) {
 	item.AddError(err)
 }
 
-func (w *Worker) checkDebriefingOfFlight(err error) (faces.IChan, faces.Name) {
+// findRoute returns the channel of the first route which accepts the item.
+func (w *Worker) findRoute(item faces.IItem) (faces.IChan, faces.Name, bool) {
+	if w.typ != faces.WorkerManagerType {
+		return nil, "", false
+	}
+
+	w.RLock()
+	defer w.RUnlock()
+
+	for _, route := range w.routes {
+		if route.Predicate(item) {
+			return route.Ch, route.Name, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// routeTo returns the input channel of handler which was set up with IItem.RouteTo.
+// Item goes to the error handlers if handler is not found, it's stopped or item has exceeded the hop limit.
+func (w *Worker) routeTo(item faces.IItem) (faces.IChan, faces.Name) {
+	name := item.GetRouteTo()
+	hops := item.Hop()
+
+	w.RLock()
+	ch, find := w.stages[name]
+	maxHops := w.maxHops
+	w.RUnlock()
+
+	var err error
+	switch {
+	case !find:
+		err = errors.Errorf("route to unknown handler '%s'", name)
+	case !ch.IsActive():
+		err = errors.Errorf("route to '%s': handler is stopped", name)
+	case hops > maxHops:
+		err = errors.Errorf("route to '%s': hop limit %d is exceeded", name, maxHops)
+	default:
+		return ch, name
+	}
+
+	logError(w.name, err, item)
+
+	return w.errCh, faces.ErrorName
+}
+
+// resume returns the channel of worker handler which the repaired item is sent back to, see faces.IItem.Resume.
+// Item goes to the final handlers if handler is not found, it's stopped or item has exceeded the resume limit.
+func (w *Worker) resume(item faces.IItem) (faces.IChan, faces.Name) {
+	name := item.GetResume()
+	resumes := item.Resumed()
+
+	w.RLock()
+	ch, find := w.stages[name]
+	maxResumes := w.maxResumes
+	w.RUnlock()
+
+	var err error
+	switch {
+	case !find:
+		err = errors.Errorf("resume to unknown handler '%s'", name)
+	case !ch.IsActive():
+		err = errors.Errorf("resume to '%s': handler is stopped", name)
+	case resumes > maxResumes:
+		err = errors.Errorf("resume to '%s': resume limit %d is exceeded", name, maxResumes)
+	default:
+		item.LogTraceFinishTimef("[%s] resumes to [%s]", w.name, name)
+		item.CleanError()
+		item.SetHandlerError(faces.EmptySkipName)
+
+		return ch, name
+	}
+
+	logError(w.name, err, item)
+
+	return w.out, faces.ErrorName
+}
+
+func (w *Worker) checkDebriefingOfFlight(err error, item faces.IItem) (faces.IChan, faces.Name) {
 	switch w.typ {
 	case faces.FinalManagerType:
 		if !w.isLast {
 			return w.out, w.nextManagerName
 		}
 	case faces.ErrorManagerType:
+		if err == nil && item.GetResume() != faces.EmptySkipName {
+			return w.resume(item)
+		}
+
 		return w.out, faces.ErrorName
 	case faces.WorkerManagerType:
 		if err == nil {
+			if item.GetRouteTo() != faces.EmptySkipName {
+				return w.routeTo(item)
+			}
+
+			if ch, name, ok := w.findRoute(item); ok {
+				return ch, name
+			}
+
 			return w.out, w.nextManagerName
 		} else {
 			return w.errCh, faces.ErrorName